@@ -0,0 +1,97 @@
+package main
+
+// ----------------------------------------------------------------------------------
+// xtlog: durchsucht den log/yyyy/mm Baum einer xt-Anwendung
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+//
+// xtlog [-dir=log] [-pfx=gstock] [-from="2020-02-01 08:00"] [-to=2020-02-02] [-i] [pattern]
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/waldurbas/xt"
+)
+
+func main() {
+	if xt.ParamExists([]string{"h", "?", "help"}) {
+		usage()
+		return
+	}
+
+	from, err := parseTime(xt.ParamValue("from", ""), false)
+	if err != nil {
+		xt.FatalF("xtlog: -from: %v", err)
+	}
+
+	to, err := parseTime(xt.ParamValue("to", ""), true)
+	if err != nil {
+		xt.FatalF("xtlog: -to: %v", err)
+	}
+
+	q := xt.LogQuery{
+		Dir:        xt.ParamValue("dir", ""),
+		Prefix:     xt.ParamValue("pfx", ""),
+		From:       from,
+		To:         to,
+		Pattern:    xt.Param(0, ""),
+		IgnoreCase: xt.ParamKeyExist("i"),
+	}
+
+	showFile := xt.ParamKeyExist("f")
+	err = xt.SearchLog(q, func(l xt.LogLine) error {
+		if showFile {
+			_, err := fmt.Printf("%s: %s\n", l.File, l.Line)
+			return err
+		}
+		_, err := fmt.Println(l.Line)
+		return err
+	})
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "xtlog:", err)
+		os.Exit(1)
+	}
+}
+
+// parseTime #akzeptiert YYYY-MM-DD [hh:mm[:ss]], bei endOfDay ohne Uhrzeit 23:59:59
+func parseTime(s string, endOfDay bool) (time.Time, error) {
+	if len(s) == 0 {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04:05"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return t, err
+	}
+
+	if endOfDay {
+		t = time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, time.Local)
+	}
+
+	return t, nil
+}
+
+func usage() {
+	fmt.Println(`xtlog [options] [pattern]
+
+  -dir=<dir>     log-Verzeichnis (default: ./log)
+  -pfx=<prefix>  Prefix der Log-Dateien (<pfx>YYYYMMDD.log[.gz])
+  -from=<time>   ab YYYY-MM-DD [hh:mm[:ss]]
+  -to=<time>     bis YYYY-MM-DD [hh:mm[:ss]] (default: jetzt)
+  -i             Gross-/Kleinschreibung ignorieren
+  -f             Dateiname mit ausgeben`)
+}
@@ -98,7 +98,7 @@ func (f *DownloadFile) Download(toFile string) error {
 	// Get the data
 	resp, err := http.Get(f.parent.url + "/" + f.FileName + ".gz")
 	if err != nil {
-		return err
+		return RedactError(err)
 	}
 	defer resp.Body.Close()
 
@@ -106,7 +106,7 @@ func (f *DownloadFile) Download(toFile string) error {
 	counter := &WriteCounter{}
 	_, err = io.Copy(out, io.TeeReader(resp.Body, counter))
 	if err != nil {
-		return err
+		return RedactError(err)
 	}
 
 	// The progress use the same line so print a new line once it's finished downloading
@@ -114,7 +114,7 @@ func (f *DownloadFile) Download(toFile string) error {
 	out.Close()
 
 	// Rename the tmp file back to the original file
-	time.Sleep(2 * time.Second)
+	Sleep(2 * time.Second)
 	err = os.Rename(tmpFile, toFile)
 	if err != nil {
 		return err
@@ -151,7 +151,7 @@ func (flist *DownloadFiles) GetFileInfo(FileName string) (*DownloadFile, error)
 			//			dif := f.Loc.Time.Sub(f.Web.Time)
 			f.Changed = (f.Web.Size != f.Loc.Size) || (f.Loc.Time != f.Web.Time)
 
-			if Global.Debug > 0 {
+			if DebugLevel() > 0 {
 				fmt.Printf("webFile: %d %v\n", f.Web.Size, f.Web.Time)
 				fmt.Printf("locFile: %d %v\n", f.Loc.Size, f.Loc.Time)
 			}
@@ -167,13 +167,13 @@ func urlDownloadListFile(url string) (string, error) {
 	// Get the data
 	resp, err := http.Get(url)
 	if err != nil {
-		return "", err
+		return "", RedactError(err)
 	}
 	defer resp.Body.Close()
 
 	data, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return "", RedactError(err)
 	}
 
 	return string(data), nil
@@ -183,7 +183,7 @@ func urlDownloadListFile(url string) (string, error) {
 func URLfileSize(url string) (int, error) {
 	resp, err := http.Head(url)
 	if err != nil {
-		return 0, err
+		return 0, RedactError(err)
 	}
 
 	// Is our request ok?
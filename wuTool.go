@@ -19,10 +19,13 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 // GlobalData #
+// Xargs, Debug und die Positions-Parameter werden wie bisher bei init aus os.Args gefuellt;
+// setzt das Programm vor dem ersten Param-Zugriff einen anderen ParseMode, ersetzt DefaultParams sie
 type GlobalData struct {
 	CurrentDir    string
 	PathSeparator string
@@ -30,9 +33,6 @@ type GlobalData struct {
 	Debug         int
 
 	xargsWithOut []string
-	logDir       string
-	logPfx       string
-	logFileName  string
 }
 
 // Global #
@@ -49,226 +49,134 @@ func init() {
 		Global.CurrentDir = dir
 	}
 
-	Global.logDir = Global.CurrentDir + Global.PathSeparator + "log"
-	Global.Xargs = make(map[string]string)
-
-	var prev string
-	for _, v := range os.Args[1:] {
-		if v[0] == '-' || v[0] == '/' {
-			prev = strings.ToLower(v[1:2])
-			if prev == "q" || prev == "x" {
-				Global.Xargs[prev] = v[2:]
-			} else {
-				ix := strings.Index(v, "=")
-				prev = ""
-				if ix > 0 {
-					prev = strings.ToLower(v[1:ix])
-					Global.Xargs[prev] = v[ix+1:]
-				} else {
-					prev = strings.ToLower(v[1:])
-					Global.Xargs[prev] = ""
-				}
-			}
-		} else {
-			Global.xargsWithOut = append(Global.xargsWithOut, v)
-			if len(prev) > 0 {
-				if len(Global.Xargs[prev]) == 0 {
-					Global.Xargs[prev] = v
-				}
-			}
-		}
-	}
+	defaultLog.dir = Global.CurrentDir + Global.PathSeparator + "log"
 
-	ParamValueCheck("debug", "1")
-	Global.Debug = ParamAsInt("debug", 0)
+	initParams()
 }
 
-// SetLog #
+// SetLog #setzt Prefix und Verzeichnis des DefaultLogger
 func SetLog(logPfx string, logDir string) {
-	if len(logDir) > 0 {
-		Global.logDir = logDir
-	}
+	defaultLog.SetLog(logPfx, logDir)
+}
 
-	Global.logPfx = logPfx
+// DebugLevel #Wert von -debug
+func DebugLevel() int {
+	DefaultParams()
+	return Global.Debug
 }
 
 // Param #
 func Param(ix int, def string) string {
-	if ix >= len(Global.xargsWithOut) {
-		return def
-	}
-
-	return Global.xargsWithOut[ix]
+	return DefaultParams().Param(ix, def)
 }
 
-// ParamValue #
+// ParamValue #Kommandozeile, Umgebung (SetEnvPrefix), Konfigurationsdatei, def
 func ParamValue(sKey string, def string) string {
-	lKey, ok := ParamValueExist(sKey)
-	if !ok {
-		return def
-	}
-
-	return Global.Xargs[lKey]
+	return DefaultParams().Value(sKey, def)
 }
 
 // ParamKeyExist #
 func ParamKeyExist(sKey string) bool {
-	_, ok := ParamExist(sKey)
-	return ok
+	return DefaultParams().KeyExist(sKey)
 }
 
-// ParamExist #
+// ParamExist #wie ParamValue ohne Default
 func ParamExist(sKey string) (string, bool) {
-	lKey := strings.ToLower(sKey)
-
-	v, ok := Global.Xargs[lKey]
-	//	fmt.Println("ParamExist.Key: ", uKey, ", ok: ", ok, ", v: ", v)
-
-	return v, ok
+	return DefaultParams().Exist(sKey)
 }
 
 // ParamExists #
 func ParamExists(sKeys []string) bool {
-	for _, k := range sKeys {
-		if ParamKeyExist(k) {
-			return true
-		}
-	}
-
-	return false
+	return DefaultParams().Exists(sKeys)
 }
 
 // ParamValueExist #
 func ParamValueExist(sKey string) (string, bool) {
-	lKey := strings.ToLower(sKey)
-	v, ok := Global.Xargs[lKey]
-	return lKey, ok && len(v) > 0
+	return DefaultParams().ValueExist(sKey)
 }
 
 // ParamAsInt #
 func ParamAsInt(sKey string, def int) int {
-	v, ok := ParamExist(sKey)
-	if !ok || len(v) == 0 {
-		return def
-	}
+	return DefaultParams().AsInt(sKey, def)
+}
 
-	return Esubstr2int(v, 0, 10)
+// ParamValues #alle Werte eines wiederholten Parameters
+func ParamValues(sKey string) []string {
+	return DefaultParams().Values(sKey)
 }
 
 // ParamSet #
 func ParamSet(sKey string, def string) {
-	lKey := strings.ToLower(sKey)
-	Global.Xargs[lKey] = def
+	DefaultParams().Set(sKey, def)
 }
 
 // ParamValueCheck #
 func ParamValueCheck(sKey string, def string) {
-	v, ok := ParamExist(sKey)
-
-	if ok && len(v) == 0 {
-		ParamSet(sKey, def)
-	}
+	DefaultParams().ValueCheck(sKey, def)
 }
 
-// PrintParam #
+// PrintParam #geheime Werte (siehe AddSecretParam) werden geschwaerzt
 func PrintParam() {
-	fmt.Println("\n--> xParams:")
-	for i, v := range Global.xargsWithOut {
-		fmt.Printf("%d. [%s]\n", i, v)
-	}
-
-	fmt.Println("----------------------------")
-
-	var sk []string
-	for k := range Global.Xargs {
-		sk = append(sk, k)
-	}
-	sort.Strings(sk)
-
-	for _, k := range sk {
-		fmt.Printf("%-16.16s: [%s]\n", k, Global.Xargs[k])
-	}
-	fmt.Print("\n\n")
+	DefaultParams().Print()
 }
 
-// PermitWeekDay for
+// PermitWeekDay for mo,di,mi,do,fr,sa,so, mon..sun, pn..nd (RegisterLocale) bzw. 0..6
+// "ho": auch an Feiertagen, "!ho": nie an Feiertagen (SetHolidayCalendar)
 func PermitWeekDay(t time.Time, sDays []string) bool {
-	ih := int(t.Weekday())
 	ok := false
-	for i := 0; i < len(sDays) && !ok; i++ {
-		switch strings.ToLower(sDays[i]) {
-		case "mo", "1":
-			ok = ih == 1
-		case "di", "2":
-			ok = ih == 2
-		case "mi", "3":
-			ok = ih == 3
-		case "do", "4":
-			ok = ih == 4
-		case "fr", "5":
-			ok = ih == 5
-		case "sa", "6":
-			ok = ih == 6
-		case "so", "0":
-			ok = ih == 0
+	for _, s := range sDays {
+		switch strings.ToLower(s) {
+		case holidayToken:
+			ok = ok || IsHoliday(t)
+		case "!" + holidayToken:
+			if IsHoliday(t) {
+				return false
+			}
+		default:
+			if wd, isDay := weekdayToken(s); isDay && wd == t.Weekday() {
+				ok = true
+			}
 		}
 	}
 
 	return ok
 }
 
-// PermitHour # array: [ "12:00-18:00","1400-2200"]
+// PermitHour # array: [ "12:00-18:00","1400-2200","800-1700","22:00-06:00"], Endminute inklusive, siehe WindowSet
+// ungueltige Eintraege werden ins Log geschrieben, siehe PermitHourErr
 func PermitHour(t time.Time, sh []string) bool {
-	tt := t.Hour()*100 + t.Minute()
 	ok := false
-
-	var vt int
-	var bt int
-	for i := 0; i < len(sh) && !ok; i++ {
-		s := strings.Split(sh[i], "-")
-
-		if len(s) == 1 {
-			vt = Esubstr2int(s[0], 0, 5)
-			bt = 2400
-		} else {
-			vt = Esubstr2int(s[0], 0, 5)
-			bt = Esubstr2int(s[1], 0, 5)
+	for _, s := range sh {
+		w, err := permitWindow(s)
+		if err != nil {
+			// jeder ungueltige Eintrag nur einmal, PermitHour laeuft meist in einer Schleife
+			if _, logged := permitLogged.LoadOrStore(s, true); !logged {
+				defaultLog.ErrorF("PermitHour: %v", err)
+			}
+			continue
 		}
-
-		ok = tt >= vt && tt <= bt
+		ok = ok || w.Contains(t)
 	}
 
 	return ok
 }
 
+// permitLogged #ungueltige PermitHour-Eintraege, die bereits im Log stehen
+var permitLogged sync.Map
+
 // Fatal #Error
 func Fatal(v ...interface{}) {
-	stime := STime(time.Now())
-	fmt.Printf("\n%s", stime)
-	s := fmt.Sprint(v...)
-	fmt.Print(s)
-	fmt.Print("\n")
-
-	_log(stime, s)
-
-	os.Exit(1)
+	fatalCode(1, false, v...)
 }
 
 // FatalF #Formatiert
 func FatalF(format string, v ...interface{}) {
-	s := fmt.Sprintf(format, v...)
-
-	_logx(s)
-
-	os.Exit(1)
+	fatalCodeF(1, false, format, v...)
 }
 
 // LogF #Format-Function
 func LogF(format string, v ...interface{}) (ss string) {
-	s := fmt.Sprintf(format, v...)
-
-	ss = _logx(s)
-	return
+	return defaultLog.LogF(format, v...)
 }
 
 // PrintStdErr #
@@ -281,95 +189,44 @@ func PrintStdErr(format string, v ...interface{}) (ss string) {
 
 // Log #Function
 func Log(v ...interface{}) {
-	s := fmt.Sprint(v...)
-
-	_logx(s)
-}
-
-func _logx(s string) (ss string) {
-	buf := []rune(s)
-
-	for len(buf) > 0 && (buf[0] == '\r' || buf[0] == '\n') {
-		fmt.Fprint(os.Stderr, string(buf[0]))
-		buf = buf[1:len(buf)]
-	}
-
-	stime := STime(time.Now())
-	if len(buf) > 0 {
-		fmt.Fprint(os.Stderr, stime)
-		e := buf[len(buf)-1]
-		if e == '#' {
-			buf = buf[:len(buf)-1]
-		}
-		fmt.Fprint(os.Stderr, string(buf))
-		ss = stime + string(buf)
-
-		if e != '#' {
-			fmt.Fprint(os.Stderr, "\n")
-		}
-	}
-	_log(stime, string(buf))
-
-	return
-}
-
-func _log(stime string, s string) {
-	sti := FTime()[0:8]
-
-	Global.logFileName = Global.logDir + Global.PathSeparator + sti[0:4] + Global.PathSeparator + sti[4:6]
-
-	CreateDirIfNotExist(Global.logFileName)
-
-	Global.logFileName = Global.logFileName + Global.PathSeparator + Global.logPfx + sti + ".log"
-
-	txt := "\n"
-	if len(s) > 0 {
-		txt = txt + stime + " " + s
-	}
-	AppendFile(Global.logFileName, txt)
+	defaultLog.Log(v...)
 }
 
 // STime  #asString for Log
 func STime(t time.Time) string {
-	return fmt.Sprintf("%d-%02d-%02d %02d:%02d:%02d ",
-		t.Year(), t.Month(), t.Day(),
-		t.Hour(), t.Minute(), t.Second())
+	return FormatTime(t, "YYYY-MM-DD hh:mm:ss ")
 }
 
 // FTime #asString for FileName
 func FTime() string {
-	t := time.Now()
-	return fmt.Sprintf("%d%02d%02d%02d%02d%02d",
-		t.Year(), t.Month(), t.Day(),
-		t.Hour(), t.Minute(), t.Second())
+	return FormatTime(Now(), "YYYYMMDDhhmmss")
 }
 
-// TimeDif #
+// TimeDif #bei negativer Differenz sind alle Werte <= 0
 func TimeDif(tA time.Time, tL time.Time) (xs int, hh int, mm int, ss int) {
-	dif := tL.Sub(tA)
-	hh = int(dif.Hours())
-	mm = int(dif.Minutes())
-	ss = int(dif.Seconds())
-	xs = ss
+	xs = int(tL.Sub(tA) / time.Second)
 
-	if hh > 0 {
-		mm -= hh * 60
-		ss -= hh * 3600
+	a := xs
+	if a < 0 {
+		a = -a
 	}
 
-	if mm > 0 {
-		ss -= mm * 60
+	hh, mm, ss = a/3600, a/60%60, a%60
+	if xs < 0 {
+		hh, mm, ss = -hh, -mm, -ss
 	}
 
 	return
 }
 
-// STimeDif #Differenz as String
+// STimeDif #Differenz as String, hh:mm:ss mit unbegrenzten Stunden, siehe ParseDuration und FormatDuration
 func STimeDif(tA time.Time, tL time.Time) string {
+	xs, hh, mm, ss := TimeDif(tA, tL)
+	if xs < 0 {
+		return fmt.Sprintf("-%.2d:%.2d:%.2d", -hh, -mm, -ss)
+	}
 
-	_, hh, mm, ss := TimeDif(tA, tL)
-	s := fmt.Sprintf("%.2d:%.2d:%.2d", hh, mm, ss)
-	return s
+	return fmt.Sprintf("%.2d:%.2d:%.2d", hh, mm, ss)
 }
 
 // LoadFiles #string
@@ -378,14 +235,16 @@ func LoadFiles(path, match string) (files []string, err error) {
 	d, err := os.Open(path)
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		Exit(1)
+		return nil, err
 	}
 	defer d.Close()
 
 	dfiles, err := d.Readdir(-1)
 	if err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		Exit(1)
+		return nil, err
 	}
 
 	for _, fInfo := range dfiles {
@@ -408,6 +267,31 @@ func LoadFiles(path, match string) (files []string, err error) {
 	return files, nil
 }
 
+// LoadDirs #liefert sortiert die Unterverzeichnisse die match entsprechen
+func LoadDirs(path, match string) (dirs []string, err error) {
+	d, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	dInfos, err := d.Readdir(-1)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fInfo := range dInfos {
+		if fInfo.IsDir() {
+			if ok, _ := filepath.Match(match, fInfo.Name()); ok {
+				dirs = append(dirs, fInfo.Name())
+			}
+		}
+	}
+
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
 // CreateDirIfNotExist #
 func CreateDirIfNotExist(dirName string) bool {
 	if _, err := os.Stat(dirName); os.IsNotExist(err) {
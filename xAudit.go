@@ -0,0 +1,389 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xAudit.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditRecord #ein Eintrag im Audit-Log
+// Zeile: <STime> {"seq":..,"time":..,"action":..,"msg":..,"prev":..,"hash":..}
+type AuditRecord struct {
+	Seq    uint64 `json:"seq"`
+	Time   string `json:"time"`
+	Action string `json:"action"`
+	Msg    string `json:"msg"`
+	Prev   string `json:"prev"`
+	Hash   string `json:"hash"`
+}
+
+// AuditLog #Audit-Trail mit Hash-Verkettung neben dem Tages-Log
+type AuditLog struct {
+	mu       sync.Mutex
+	dir      string
+	pfx      string
+	tf       LogTimeFormat
+	seq      uint64
+	prev     string
+	fileName string
+	size     int64
+}
+
+// auditLockStale #aelter ist die Sperre eines abgestuerzten Prozesses
+const auditLockStale = 10 * time.Second
+
+// AuditBreak #Stelle, an der die Kette nicht stimmt
+type AuditBreak struct {
+	File   string
+	Line   int
+	Seq    uint64
+	Reason string
+}
+
+func (b AuditBreak) String() string {
+	return fmt.Sprintf("%s:%d: seq %d: %s", b.File, b.Line, b.Seq, b.Reason)
+}
+
+// NewAuditLog #setzt die Kette am letzten Eintrag unter <dir>/yyyy/mm/<pfx>yyyymmdd.log fort
+// mehrere Prozesse auf demselben dir/pfx: Record sperrt ueber <dir>/<pfx>.lock
+// und liest den letzten Eintrag neu, wenn ein anderer Schreiber dazwischen war
+func NewAuditLog(pfx string, dir string) (*AuditLog, error) {
+	if len(dir) == 0 {
+		dir = defaultLog.Dir()
+	}
+
+	a := &AuditLog{dir: dir, pfx: pfx, tf: DefaultLogTimeFormat}
+
+	last, err := lastAuditRecord(dir, pfx)
+	if err != nil {
+		return nil, err
+	}
+
+	if last != nil {
+		a.seq = last.Seq
+		a.prev = last.Hash
+	}
+
+	return a, nil
+}
+
+// Seq #letzte vergebene Nummer
+func (a *AuditLog) Seq() uint64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.seq
+}
+
+// FileName #zuletzt beschriebene Datei
+func (a *AuditLog) FileName() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.fileName
+}
+
+// Record #schreibt einen Eintrag und liefert ihn zurueck
+func (a *AuditLog) Record(action string, format string, v ...interface{}) (AuditRecord, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := Now()
+	r := AuditRecord{
+		Time:   now.Format(time.RFC3339Nano),
+		Action: action,
+		Msg:    fmt.Sprintf(format, v...),
+	}
+
+	mDir, fileName := logFilePath(a.dir, a.pfx, now, a.tf)
+	CreateDirIfNotExist(mDir)
+
+	unlock, err := lockAudit(PathJoin(a.dir, a.pfx+".lock"))
+	if err != nil {
+		return r, err
+	}
+	defer unlock()
+
+	if err := a.resync(fileName); err != nil {
+		return r, err
+	}
+
+	r.Seq = a.seq + 1
+	r.Prev = a.prev
+	r.Hash = auditHash(&r)
+
+	data, err := json.Marshal(&r)
+	if err != nil {
+		return r, err
+	}
+
+	a.fileName = fileName
+	if err := appendSync(a.fileName, STimeOpt(now, a.tf)+string(data)+"\n"); err != nil {
+		return r, err
+	}
+
+	a.seq = r.Seq
+	a.prev = r.Hash
+	a.size = fileSize(a.fileName)
+	return r, nil
+}
+
+// resync #liest den letzten Eintrag neu, wenn ein anderer Prozess geschrieben hat
+func (a *AuditLog) resync(fileName string) error {
+	if len(a.fileName) > 0 && fileSize(a.fileName) == a.size &&
+		(fileName == a.fileName || !FileExists(fileName)) {
+		return nil
+	}
+
+	last, err := lastAuditRecord(a.dir, a.pfx)
+	if err != nil {
+		return err
+	}
+
+	a.seq, a.prev = 0, ""
+	if last != nil {
+		a.seq = last.Seq
+		a.prev = last.Hash
+	}
+
+	return nil
+}
+
+func fileSize(path string) int64 {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return -1
+	}
+	return fi.Size()
+}
+
+// lockAudit #exklusive Sperrdatei, verwaiste Sperren werden nach auditLockStale uebernommen
+func lockAudit(path string) (func(), error) {
+	deadline := time.Now().Add(2 * auditLockStale)
+
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0666)
+		if err == nil {
+			f.WriteString(strconv.Itoa(os.Getpid()))
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		if removeStale(path, func() bool {
+			fi, serr := os.Stat(path)
+			return serr == nil && time.Since(fi.ModTime()) > auditLockStale
+		}) {
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("audit lock %s: timeout", path)
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// removeStale #entfernt eine verwaiste Sperre nur unter der Hilfssperre <path>.takeover und nach
+// erneuter Pruefung, so entfernt kein Prozess die gerade frisch angelegte Sperre eines anderen
+func removeStale(path string, stale func() bool) bool {
+	if !stale() {
+		return false
+	}
+
+	guard := path + ".takeover"
+	f, err := os.OpenFile(guard, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0666)
+	if err != nil {
+		// Hilfssperre eines waehrend der Uebernahme abgestuerzten Prozesses
+		if fi, serr := os.Stat(guard); serr == nil && time.Since(fi.ModTime()) > auditLockStale {
+			os.Remove(guard)
+		}
+		return false
+	}
+	f.Close()
+	defer os.Remove(guard)
+
+	return stale() && os.Remove(path) == nil
+}
+
+func auditHash(r *AuditRecord) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d\x00%s\x00%s\x00%s\x00%s", r.Seq, r.Time, r.Action, r.Msg, r.Prev)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func appendSync(path string, data string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(data); err != nil {
+		return err
+	}
+
+	return f.Sync()
+}
+
+// VerifyAudit #prueft eine Datei oder den log/yyyy/mm Baum (nur Dateien mit pfx)
+// beim Baum muss die Kette mit dem Genesis-Eintrag (seq 1, ohne prev) beginnen,
+// eine einzelne Tagesdatei wird nur in sich geprueft
+func VerifyAudit(path string, pfx string) ([]AuditBreak, error) {
+	anchored := !FileExists(path)
+	files, err := auditFiles(path, pfx)
+	if err != nil {
+		return nil, err
+	}
+
+	var breaks []AuditBreak
+	var prev *AuditRecord
+
+	for _, f := range files {
+		err := readAudit(f, func(line int, r *AuditRecord, perr error) {
+			if perr != nil {
+				breaks = append(breaks, AuditBreak{File: f, Line: line, Reason: perr.Error()})
+				return
+			}
+
+			if h := auditHash(r); h != r.Hash {
+				breaks = append(breaks, AuditBreak{File: f, Line: line, Seq: r.Seq, Reason: "hash mismatch (record modified)"})
+			}
+
+			if prev == nil && anchored && (r.Seq != 1 || len(r.Prev) > 0) {
+				breaks = append(breaks, AuditBreak{File: f, Line: line, Seq: r.Seq, Reason: "chain does not start with genesis record"})
+			}
+
+			if prev != nil {
+				if r.Prev != prev.Hash {
+					breaks = append(breaks, AuditBreak{File: f, Line: line, Seq: r.Seq, Reason: "prev hash does not match previous record"})
+				}
+				if r.Seq != prev.Seq+1 {
+					breaks = append(breaks, AuditBreak{File: f, Line: line, Seq: r.Seq,
+						Reason: "sequence gap: expected " + strconv.FormatUint(prev.Seq+1, 10)})
+				}
+			}
+
+			prev = r
+		})
+
+		if err != nil {
+			return breaks, err
+		}
+	}
+
+	return breaks, nil
+}
+
+// auditFiles #einzelne Datei oder alle <pfx>yyyymmdd.log im Baum, zeitlich sortiert
+func auditFiles(path string, pfx string) ([]string, error) {
+	if FileExists(path) {
+		return []string{path}, nil
+	}
+
+	var files []string
+	years, err := LoadDirs(path, "[0-9][0-9][0-9][0-9]")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, y := range years {
+		months, _ := LoadDirs(PathJoin(path, y), "[0-9][0-9]")
+		for _, m := range months {
+			days, err := logFilesByDay(PathJoin(path, y, m), pfx)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, d := range days {
+				files = append(files, d.files...)
+			}
+		}
+	}
+
+	return files, nil
+}
+
+func readAudit(fileName string, fn func(line int, r *AuditRecord, err error)) error {
+	f, err := openLogFile(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	line := 0
+	for sc.Scan() {
+		line++
+		s := string(dropCR(sc.Bytes()))
+		if len(s) == 0 {
+			continue
+		}
+
+		ix := strings.Index(s, "{")
+		if ix < 0 {
+			fn(line, nil, fmt.Errorf("no audit record"))
+			continue
+		}
+
+		var r AuditRecord
+		if err := json.Unmarshal([]byte(s[ix:]), &r); err != nil {
+			fn(line, nil, fmt.Errorf("invalid record: %v", err))
+			continue
+		}
+
+		fn(line, &r, nil)
+	}
+
+	return sc.Err()
+}
+
+func lastAuditRecord(dir string, pfx string) (*AuditRecord, error) {
+	if !DirExists(dir) {
+		return nil, nil
+	}
+
+	files, err := auditFiles(dir, pfx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(files) - 1; i >= 0; i-- {
+		var last *AuditRecord
+		err := readAudit(files[i], func(line int, r *AuditRecord, err error) {
+			if r != nil {
+				last = r
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if last != nil {
+			return last, nil
+		}
+	}
+
+	return nil, nil
+}
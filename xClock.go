@@ -0,0 +1,196 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xClock.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Clock #Zeitquelle fuer Log, FTime, Stopwatch, JobRunner und Download, siehe SetClock
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+	Sleep(d time.Duration)
+}
+
+// Timer #wie time.Timer
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+// RealClock #time.Now, time.NewTimer, time.Sleep
+type RealClock struct{}
+
+// Now #
+func (RealClock) Now() time.Time { return time.Now() }
+
+// NewTimer #
+func (RealClock) NewTimer(d time.Duration) Timer { return realTimer{time.NewTimer(d)} }
+
+// Sleep #
+func (RealClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+type realTimer struct{ t *time.Timer }
+
+func (t realTimer) C() <-chan time.Time { return t.t.C }
+func (t realTimer) Stop() bool          { return t.t.Stop() }
+
+// clock #atomic.Value braucht immer denselben konkreten Typ, leer = RealClock
+var clock atomic.Value
+
+type clockBox struct{ c Clock }
+
+// SetClock #nil = RealClock
+func SetClock(c Clock) {
+	if c == nil {
+		c = RealClock{}
+	}
+
+	clock.Store(clockBox{c})
+}
+
+// GetClock #
+func GetClock() Clock {
+	if b, ok := clock.Load().(clockBox); ok {
+		return b.c
+	}
+	return RealClock{}
+}
+
+// Now #aktuelle Zeit der eingestellten Clock
+func Now() time.Time {
+	return GetClock().Now()
+}
+
+// Since #wie time.Since mit der eingestellten Clock
+func Since(t time.Time) time.Duration {
+	return Now().Sub(t)
+}
+
+// Sleep #
+func Sleep(d time.Duration) {
+	GetClock().Sleep(d)
+}
+
+// NewTimer #
+func NewTimer(d time.Duration) Timer {
+	return GetClock().NewTimer(d)
+}
+
+// FakeClock #steuerbare Zeit fuer Tests: Set, Advance; Timer laufen ab, sobald die Zeit erreicht ist
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	fc     *FakeClock
+	c      chan time.Time
+	at     time.Time
+	active bool
+}
+
+// NewFakeClock #
+func NewFakeClock(t time.Time) *FakeClock {
+	return &FakeClock{now: t}
+}
+
+// Now #
+func (f *FakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Set #auch rueckwaerts, faellige Timer laufen ab
+func (f *FakeClock) Set(t time.Time) {
+	f.mu.Lock()
+	f.now = t
+	f.fire()
+	f.mu.Unlock()
+}
+
+// Advance #
+func (f *FakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	f.now = f.now.Add(d)
+	f.fire()
+	f.mu.Unlock()
+}
+
+// Sleep #wartet, bis die Uhr (Set, Advance) um d weiter steht; zaehlt bei Timers mit
+func (f *FakeClock) Sleep(d time.Duration) {
+	<-f.NewTimer(d).C()
+}
+
+// NewTimer #
+func (f *FakeClock) NewTimer(d time.Duration) Timer {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	t := &fakeTimer{fc: f, c: make(chan time.Time, 1), at: f.now.Add(d), active: true}
+	f.timers = append(f.timers, t)
+	f.fire()
+
+	return t
+}
+
+// Timers #Anzahl wartender Timer, z.B. um in Tests auf eine Goroutine zu warten
+func (f *FakeClock) Timers() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.timers)
+}
+
+// fire #faellige Timer nach Ablaufzeit; Aufruf unter f.mu
+func (f *FakeClock) fire() {
+	sort.SliceStable(f.timers, func(i, j int) bool { return f.timers[i].at.Before(f.timers[j].at) })
+
+	n := 0
+	for _, t := range f.timers {
+		if t.at.After(f.now) {
+			f.timers[n] = t
+			n++
+			continue
+		}
+
+		t.active = false
+		t.c <- f.now
+	}
+	f.timers = f.timers[:n]
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.c }
+
+func (t *fakeTimer) Stop() bool {
+	f := t.fc
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !t.active {
+		return false
+	}
+
+	t.active = false
+	for i, x := range f.timers {
+		if x == t {
+			f.timers = append(f.timers[:i], f.timers[i+1:]...)
+			break
+		}
+	}
+
+	return true
+}
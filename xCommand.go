@@ -0,0 +1,312 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xCommand.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Command #Kommando bzw. Unterkommando, z.B. "gstock import"
+type Command struct {
+	Name    string
+	Aliases []string
+	Desc    string
+	Params  *ParamDefs                // eigene Parameter, gelten auch fuer Unterkommandos
+	Run     func(args []string) error // args: restliche Positions-Parameter
+	Default string                    // Unterkommando ohne Angabe, z.B. "update"
+
+	parent *Command
+	subs   []*Command
+}
+
+// Add #haengt sub an und liefert sub; Name und Aliase muessen eindeutig sein, sonst panic
+func (c *Command) Add(sub *Command) *Command {
+	for _, n := range append([]string{sub.Name}, sub.Aliases...) {
+		if c.Find(n) != nil {
+			panic("Command: duplicate command: " + n)
+		}
+	}
+
+	sub.parent = c
+	c.subs = append(c.subs, sub)
+	return sub
+}
+
+// Find #Unterkommando zu Name oder Alias
+func (c *Command) Find(name string) *Command {
+	for _, s := range c.subs {
+		if strings.EqualFold(s.Name, name) {
+			return s
+		}
+		for _, a := range s.Aliases {
+			if strings.EqualFold(a, name) {
+				return s
+			}
+		}
+	}
+
+	return nil
+}
+
+// Subs #
+func (c *Command) Subs() []*Command {
+	return c.subs
+}
+
+// Path #"gstock import"
+func (c *Command) Path() string {
+	if c.parent == nil {
+		return c.Name
+	}
+	return c.parent.Path() + " " + c.Name
+}
+
+// Dispatch #Kommando zur Kommandozeile (DefaultParams) suchen, Parameter pruefen und ausfuehren
+func Dispatch(root *Command) error {
+	return root.Dispatch(DefaultParams())
+}
+
+// Dispatch #das Kommando ergibt sich aus den Positions-Parametern von p
+func (c *Command) Dispatch(p *Params) error {
+	if ok, err := writeCompletion(p, c); ok {
+		if err != nil {
+			return err
+		}
+		return ErrHelp
+	}
+
+	cmd, rest, unknown, help := c.resolve(p)
+
+	if help || p.Exists([]string{"h", "?", "help"}) {
+		fmt.Print(cmd.Help())
+		return ErrHelp
+	}
+
+	if cmd.Run == nil {
+		if len(unknown) > 0 {
+			return fmt.Errorf("%s: unknown command %q", cmd.Path(), Redact(unknown))
+		}
+		return fmt.Errorf("%s: command missing", cmd.Path())
+	}
+
+	defs, err := cmd.pathParams()
+	if err != nil {
+		return fmt.Errorf("%s: %v", cmd.Path(), err)
+	}
+
+	if err := defs.checkParams(p); err != nil {
+		return fmt.Errorf("%s: %v", cmd.Path(), err)
+	}
+
+	// alle Ebenen sehen die geprueften Werte
+	for p := cmd; p != nil; p = p.parent {
+		if p.Params != nil {
+			p.Params.values = defs.values
+		}
+	}
+
+	return cmd.Run(rest)
+}
+
+// resolve #liefert das Kommando, die restlichen Argumente, den ersten nicht passenden
+// Kommando-Namen und ob "help <cmd>" verlangt ist; Werte von Optionen sind keine Kommandos
+func (c *Command) resolve(p *Params) (cmd *Command, rest []string, unknown string, help bool) {
+	cmd = c
+	args := p.Args()
+
+	// ix #Indizes der noch nicht verbrauchten Positions-Parameter
+	ix := make([]int, len(args))
+	for i := range ix {
+		ix[i] = i
+	}
+
+	// next #Position in ix des naechsten Kandidaten fuer einen Kommando-Namen, sonst -1
+	next := func() int {
+		for n, i := range ix {
+			if !cmd.optionValue(p, i) {
+				return n
+			}
+		}
+		return -1
+	}
+
+	take := func(n int) {
+		ix = append(ix[:n], ix[n+1:]...)
+	}
+
+	if n := next(); n >= 0 && strings.EqualFold(args[ix[n]], "help") && c.Find("help") == nil {
+		help = true
+		take(n)
+	}
+
+	for len(cmd.subs) > 0 {
+		if n := next(); n >= 0 {
+			if sub := cmd.Find(args[ix[n]]); sub != nil {
+				cmd = sub
+				take(n)
+				continue
+			}
+		}
+
+		if help || len(cmd.Default) == 0 {
+			break
+		}
+
+		sub := cmd.Find(cmd.Default)
+		if sub == nil {
+			break
+		}
+		cmd = sub
+	}
+
+	if n := next(); n >= 0 && len(cmd.subs) > 0 {
+		unknown = args[ix[n]]
+	}
+
+	for _, i := range ix {
+		rest = append(rest, args[i])
+	}
+	return
+}
+
+// optionValue #Positions-Parameter i ist Wert einer Option; unbekannte Optionen behalten
+// ihren Wert (-pwd geheim), bei bool (-v import) und ModePosix-Kurzoptionen zaehlt die Deklaration
+// auf dem Weg zu c bzw. in den Unterkommandos
+func (c *Command) optionValue(p *Params, i int) bool {
+	if k, ok := p.vals[i]; ok {
+		return !c.boolParam(k)
+	}
+
+	if k, ok := p.short[i]; ok {
+		def := c.findParam(k)
+		return def != nil && def.Type != TypeBool
+	}
+
+	return false
+}
+
+// findParam #Parameter von c oder einer uebergeordneten Ebene
+func (c *Command) findParam(name string) *ParamDef {
+	for p := c; p != nil; p = p.parent {
+		if p.Params != nil {
+			if def := p.Params.Def(name); def != nil {
+				return def
+			}
+		}
+	}
+	return nil
+}
+
+// boolParam #name ist auf dem Weg zu c oder, dort nicht deklariert, in einem Unterkommando bool
+func (c *Command) boolParam(name string) bool {
+	if def := c.findParam(name); def != nil {
+		return def.Type == TypeBool
+	}
+
+	var sub func(c *Command) bool
+	sub = func(c *Command) bool {
+		for _, s := range c.subs {
+			if s.Params != nil {
+				if def := s.Params.Def(name); def != nil && def.Type == TypeBool {
+					return true
+				}
+			}
+			if sub(s) {
+				return true
+			}
+		}
+		return false
+	}
+	return sub(c)
+}
+
+// pathParams #Parameter aller Ebenen von der Wurzel bis c; gleiche Namen gelten einmal,
+// ein Alias, der einen anderen Parameter einer anderen Ebene trifft, ist ein Fehler
+func (c *Command) pathParams() (*ParamDefs, error) {
+	defs := NewParamDefs("")
+
+	var path []*Command
+	for p := c; p != nil; p = p.parent {
+		path = append([]*Command{p}, path...)
+	}
+
+	for _, p := range path {
+		if p.Params == nil {
+			continue
+		}
+
+		for _, d := range p.Params.defs {
+			if defs.Def(d.Name) != nil {
+				continue
+			}
+			if err := defs.add(*d); err != nil {
+				return defs, err
+			}
+		}
+	}
+
+	return defs, nil
+}
+
+// Help #
+func (c *Command) Help() string {
+	var b Buffer
+
+	usage := c.Path()
+	if c.Params != nil && len(c.Params.Usage) > 0 {
+		usage = c.Params.Usage
+	} else if len(c.subs) > 0 {
+		usage += " <command> [options]"
+	} else {
+		usage += " [options]"
+	}
+	b.WriteLine("usage: " + usage)
+
+	if len(c.Desc) > 0 {
+		b.WriteLine("")
+		b.WriteLine(c.Desc)
+	}
+
+	if len(c.subs) > 0 {
+		b.WriteLine("")
+		b.WriteLine("commands:")
+
+		w := 0
+		names := make([]string, len(c.subs))
+		for i, s := range c.subs {
+			names[i] = s.Name
+			if len(s.Aliases) > 0 {
+				names[i] += " (" + strings.Join(s.Aliases, ", ") + ")"
+			}
+			if strings.EqualFold(s.Name, c.Default) {
+				names[i] += " [default]"
+			}
+			if len(names[i]) > w {
+				w = len(names[i])
+			}
+		}
+
+		for i, s := range c.subs {
+			b.WriteLine(fmt.Sprintf("  %-*s  %s", w, names[i], s.Desc))
+		}
+	}
+
+	defs, _ := c.pathParams()
+	if len(defs.defs) > 0 {
+		b.WriteLine("")
+		b.WriteLine("options:")
+		h := defs.Help()
+		b.WriteString(h[strings.Index(h, "\n\n")+2:])
+	}
+
+	return b.String()
+}
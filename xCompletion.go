@@ -0,0 +1,321 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xCompletion.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CompletionShells #unterstuetzte Shells fuer -completion=<shell>
+var CompletionShells = []string{"bash", "zsh", "fish"}
+
+// compNode #Kommando-Ebene, path: "" bzw. "/import/sub"
+type compNode struct {
+	path  string
+	cmds  []string
+	opts  []string
+	enums map[string][]string // "-mode" -> Werte
+	files []string            // "-file"
+}
+
+type compTrans struct {
+	from string
+	word string
+	to   string
+}
+
+type compModel struct {
+	prog  string
+	fn    string
+	nodes []*compNode
+	trans []compTrans
+}
+
+// CompletionScript #Completion-Skript fuer prog mit allen Kommandos und Parametern von root
+func CompletionScript(shell string, prog string, root *Command) (string, error) {
+	if len(prog) == 0 {
+		prog = progName()
+	}
+
+	m := &compModel{prog: prog, fn: "_" + shellIdent(prog) + "_xt"}
+	m.add(root, "")
+
+	switch strings.ToLower(shell) {
+	case "bash":
+		return m.bash(), nil
+	case "zsh":
+		return m.zsh(), nil
+	case "fish":
+		return m.fish(), nil
+	}
+
+	return "", fmt.Errorf("completion: unknown shell %q (%s)", shell, strings.Join(CompletionShells, ", "))
+}
+
+// Completion #Completion-Skript nur mit den Parametern aus d
+func (d *ParamDefs) Completion(shell string, prog string) (string, error) {
+	return CompletionScript(shell, prog, &Command{Name: prog, Params: d})
+}
+
+// writeCompletion #-completion=<shell> ausgeben, true wenn angefordert
+func writeCompletion(p *Params, root *Command) (bool, error) {
+	shell, ok := p.Map()["completion"]
+	if !ok {
+		return false, nil
+	}
+
+	s, err := CompletionScript(shell, "", root)
+	if err != nil {
+		return true, err
+	}
+
+	fmt.Print(s)
+	return true, nil
+}
+
+func (m *compModel) add(c *Command, path string) {
+	n := &compNode{path: path, enums: make(map[string][]string)}
+
+	for _, s := range c.subs {
+		sp := path + "/" + s.Name
+		for _, w := range append([]string{s.Name}, s.Aliases...) {
+			n.cmds = append(n.cmds, w)
+			m.trans = append(m.trans, compTrans{from: path, word: w, to: sp})
+		}
+	}
+
+	n.opts = append(n.opts, "-h")
+	defs, _ := c.pathParams()
+	for _, d := range defs.defs {
+		for _, name := range append([]string{d.Name}, d.Aliases...) {
+			key := "-" + strings.ToLower(name)
+			switch d.Type {
+			case TypeBool:
+				n.opts = append(n.opts, key)
+			case TypeEnum:
+				n.opts = append(n.opts, key+"=")
+				n.enums[key] = d.Enum
+			case TypeFile:
+				n.opts = append(n.opts, key+"=")
+				n.files = append(n.files, key)
+			default:
+				n.opts = append(n.opts, key+"=")
+			}
+		}
+	}
+
+	m.nodes = append(m.nodes, n)
+
+	for _, s := range c.subs {
+		m.add(s, path+"/"+s.Name)
+	}
+}
+
+func (n *compNode) enumKeys() []string {
+	keys := make([]string, 0, len(n.enums))
+	for k := range n.enums {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (m *compModel) bash() string {
+	var b Buffer
+
+	b.WriteLine("# bash completion for " + m.prog + ", erzeugt mit: " + m.prog + " -completion=bash")
+	b.WriteLine(m.fn + "() {")
+	b.WriteLine(`    local cur prev key="" cpath="" w i opts="" cmds=""`)
+	b.WriteLine(`    COMPREPLY=()`)
+	b.WriteLine(`    cur="${COMP_WORDS[COMP_CWORD]}"`)
+	b.WriteLine(`    prev="${COMP_WORDS[COMP_CWORD-1]}"`)
+	b.WriteLine(`    if [[ "$cur" == "=" ]]; then key="$prev"; cur=""`)
+	b.WriteLine(`    elif [[ "$prev" == "=" && $COMP_CWORD -ge 2 ]]; then key="${COMP_WORDS[COMP_CWORD-2]}"`)
+	b.WriteLine(`    fi`)
+	b.WriteLine(`    for ((i=1; i<COMP_CWORD; i++)); do`)
+	b.WriteLine(`        w="${COMP_WORDS[i]}"`)
+	b.WriteLine(`        [[ "$w" == -* || "$w" == "=" || "${COMP_WORDS[i-1]}" == "=" ]] && continue`)
+	b.WriteLine(`        case "$cpath/$w" in`)
+	for _, t := range m.trans {
+		b.WriteLine(fmt.Sprintf(`            %s) cpath=%s ;;`, shQuote(t.from+"/"+t.word), shQuote(t.to)))
+	}
+	b.WriteLine(`        esac`)
+	b.WriteLine(`    done`)
+	b.WriteLine(`    if [[ -n "$key" ]]; then`)
+	b.WriteLine(`        case "$cpath $key" in`)
+	for _, n := range m.nodes {
+		for _, k := range n.enumKeys() {
+			b.WriteLine(fmt.Sprintf(`            %s) COMPREPLY=($(compgen -W %s -- "$cur")) ;;`, shQuote(n.path+" "+k), shQuote(strings.Join(n.enums[k], " "))))
+		}
+		for _, k := range n.files {
+			b.WriteLine(fmt.Sprintf(`            %s) COMPREPLY=($(compgen -f -- "$cur")) ;;`, shQuote(n.path+" "+k)))
+		}
+	}
+	b.WriteLine(`        esac`)
+	b.WriteLine(`        return 0`)
+	b.WriteLine(`    fi`)
+	b.WriteLine(`    case "$cpath" in`)
+	for _, n := range m.nodes {
+		b.WriteLine(fmt.Sprintf(`        %s) opts=%s; cmds=%s ;;`, shQuote(n.path), shQuote(strings.Join(n.opts, " ")), shQuote(strings.Join(n.cmds, " "))))
+	}
+	b.WriteLine(`    esac`)
+	b.WriteLine(`    if [[ "$cur" == -* ]]; then`)
+	b.WriteLine(`        COMPREPLY=($(compgen -W "$opts" -- "$cur"))`)
+	b.WriteLine(`        [[ "${COMPREPLY[0]}" == *= ]] && compopt -o nospace 2>/dev/null`)
+	b.WriteLine(`    elif [[ -n "$cmds" ]]; then`)
+	b.WriteLine(`        COMPREPLY=($(compgen -W "$cmds" -- "$cur"))`)
+	b.WriteLine(`    else`)
+	b.WriteLine(`        COMPREPLY=($(compgen -f -- "$cur"))`)
+	b.WriteLine(`    fi`)
+	b.WriteLine(`}`)
+	b.WriteLine("complete -F " + m.fn + " " + m.prog)
+
+	return b.String()
+}
+
+func (m *compModel) zsh() string {
+	var b Buffer
+
+	b.WriteLine("#compdef " + m.prog)
+	b.WriteLine("# zsh completion for " + m.prog + ", erzeugt mit: " + m.prog + " -completion=zsh")
+	b.WriteLine(m.fn + "() {")
+	b.WriteLine(`    local cur="${words[CURRENT]}" cpath="" w i key=""`)
+	b.WriteLine(`    local -a opts cmds`)
+	b.WriteLine(`    for ((i=2; i<CURRENT; i++)); do`)
+	b.WriteLine(`        w="${words[i]}"`)
+	b.WriteLine(`        [[ "$w" == -* ]] && continue`)
+	b.WriteLine(`        case "$cpath/$w" in`)
+	for _, t := range m.trans {
+		b.WriteLine(fmt.Sprintf(`            %s) cpath=%s ;;`, shQuote(t.from+"/"+t.word), shQuote(t.to)))
+	}
+	b.WriteLine(`        esac`)
+	b.WriteLine(`    done`)
+	b.WriteLine(`    if [[ "$cur" == -*=* ]]; then`)
+	b.WriteLine(`        key="${cur%%=*}"`)
+	b.WriteLine(`        compset -P '*='`)
+	b.WriteLine(`        case "$cpath $key" in`)
+	for _, n := range m.nodes {
+		for _, k := range n.enumKeys() {
+			b.WriteLine(fmt.Sprintf(`            %s) compadd -- %s ;;`, shQuote(n.path+" "+k), shWords(n.enums[k])))
+		}
+		for _, k := range n.files {
+			b.WriteLine(fmt.Sprintf(`            %s) _files ;;`, shQuote(n.path+" "+k)))
+		}
+	}
+	b.WriteLine(`        esac`)
+	b.WriteLine(`        return`)
+	b.WriteLine(`    fi`)
+	b.WriteLine(`    case "$cpath" in`)
+	for _, n := range m.nodes {
+		b.WriteLine(fmt.Sprintf(`        %s) opts=(%s); cmds=(%s) ;;`, shQuote(n.path), shWords(n.opts), shWords(n.cmds)))
+	}
+	b.WriteLine(`    esac`)
+	b.WriteLine(`    if [[ "$cur" == -* ]]; then`)
+	b.WriteLine(`        compadd -S '' -- ${(M)opts:#*=}`)
+	b.WriteLine(`        compadd -- ${opts:#*=}`)
+	b.WriteLine(`    elif (( ${#cmds} )); then`)
+	b.WriteLine(`        compadd -- $cmds`)
+	b.WriteLine(`    else`)
+	b.WriteLine(`        _files`)
+	b.WriteLine(`    fi`)
+	b.WriteLine(`}`)
+	b.WriteLine("compdef " + m.fn + " " + m.prog)
+
+	return b.String()
+}
+
+func (m *compModel) fish() string {
+	var b Buffer
+	fn := strings.TrimPrefix(m.fn, "_")
+
+	b.WriteLine("# fish completion for " + m.prog + ", erzeugt mit: " + m.prog + " -completion=fish")
+	b.WriteLine("function __" + fn + "_path")
+	b.WriteLine(`    set -l cpath ""`)
+	b.WriteLine(`    set -l words (commandline -opc)`)
+	b.WriteLine(`    set -e words[1]`)
+	b.WriteLine(`    for w in $words`)
+	b.WriteLine(`        string match -q -- '-*' $w; and continue`)
+	b.WriteLine(`        switch "$cpath/$w"`)
+	for _, t := range m.trans {
+		b.WriteLine(fmt.Sprintf(`            case %s`, shQuote(t.from+"/"+t.word)))
+		b.WriteLine(fmt.Sprintf(`                set cpath %s`, shQuote(t.to)))
+	}
+	b.WriteLine(`        end`)
+	b.WriteLine(`    end`)
+	b.WriteLine(`    echo $cpath`)
+	b.WriteLine(`end`)
+	b.WriteLine(``)
+	b.WriteLine("function __" + fn + "_complete")
+	b.WriteLine(`    set -l cpath (__` + fn + `_path)`)
+	b.WriteLine(`    set -l cur (commandline -ct)`)
+	b.WriteLine(`    set -l opts`)
+	b.WriteLine(`    set -l cmds`)
+	b.WriteLine(`    if string match -q -- '-*=*' $cur`)
+	b.WriteLine(`        set -l kv (string split -m1 = -- $cur)`)
+	b.WriteLine(`        switch "$cpath $kv[1]"`)
+	for _, n := range m.nodes {
+		for _, k := range n.enumKeys() {
+			b.WriteLine(fmt.Sprintf(`            case %s`, shQuote(n.path+" "+k)))
+			b.WriteLine(fmt.Sprintf(`                for v in %s; echo $kv[1]=$v; end`, shWords(n.enums[k])))
+		}
+		for _, k := range n.files {
+			b.WriteLine(fmt.Sprintf(`            case %s`, shQuote(n.path+" "+k)))
+			b.WriteLine(`                for f in (__fish_complete_path $kv[2]); echo $kv[1]=$f; end`)
+		}
+	}
+	b.WriteLine(`        end`)
+	b.WriteLine(`        return`)
+	b.WriteLine(`    end`)
+	b.WriteLine(`    switch "$cpath"`)
+	for _, n := range m.nodes {
+		b.WriteLine(fmt.Sprintf(`        case %s`, shQuote(n.path)))
+		b.WriteLine(fmt.Sprintf(`            set opts %s`, shWords(n.opts)))
+		b.WriteLine(fmt.Sprintf(`            set cmds %s`, shWords(n.cmds)))
+	}
+	b.WriteLine(`    end`)
+	b.WriteLine(`    if string match -q -- '-*' $cur`)
+	b.WriteLine(`        printf '%s\n' $opts`)
+	b.WriteLine(`    else if test (count $cmds) -gt 0`)
+	b.WriteLine(`        printf '%s\n' $cmds`)
+	b.WriteLine(`    else`)
+	b.WriteLine(`        __fish_complete_path $cur`)
+	b.WriteLine(`    end`)
+	b.WriteLine(`end`)
+	b.WriteLine(``)
+	b.WriteLine("complete -c " + m.prog + " -f -a '(__" + fn + "_complete)'")
+
+	return b.String()
+}
+
+// shQuote #'..' fuer bash, zsh und fish
+func shQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+func shWords(a []string) string {
+	q := make([]string, len(a))
+	for i, s := range a {
+		q[i] = shQuote(s)
+	}
+	return strings.Join(q, " ")
+}
+
+func shellIdent(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
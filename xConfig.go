@@ -0,0 +1,226 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xConfig.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ParamSource #Herkunft eines Parameter-Wertes
+type ParamSource int
+
+const (
+	// SourceDefault #nicht gefunden, Default des Aufrufers
+	SourceDefault ParamSource = iota
+	// SourceArg #Kommandozeile
+	SourceArg
+	// SourceEnv #Umgebungsvariable <prefix><KEY>
+	SourceEnv
+	// SourceFile #Konfigurationsdatei
+	SourceFile
+)
+
+var paramSourceNames = []string{"default", "arg", "env", "file"}
+
+func (s ParamSource) String() string {
+	if s < SourceDefault || int(s) >= len(paramSourceNames) {
+		return "source(" + strconv.Itoa(int(s)) + ")"
+	}
+	return paramSourceNames[s]
+}
+
+var config = struct {
+	sync.RWMutex
+	envPrefix string
+	fileName  string
+	file      map[string]string
+}{}
+
+// SetEnvPrefix #Parameter "pwd" wird bei Prefix "GSTOCK_" als GSTOCK_PWD gesucht, leer = keine Umgebung
+func SetEnvPrefix(pfx string) {
+	config.Lock()
+	config.envPrefix = pfx
+	config.Unlock()
+}
+
+// ParamEnvName #Name der Umgebungsvariable zum Parameter, leer ohne Prefix
+func ParamEnvName(key string) string {
+	config.RLock()
+	pfx := config.envPrefix
+	config.RUnlock()
+
+	if len(pfx) == 0 {
+		return ""
+	}
+
+	r := strings.NewReplacer(".", "_", "-", "_")
+	return pfx + strings.ToUpper(r.Replace(key))
+}
+
+// LoadConfigFile #.json, sonst INI (key=value, [section] ergibt section.key)
+func LoadConfigFile(fileName string) error {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	var m map[string]string
+	if strings.EqualFold(filepath.Ext(fileName), ".json") {
+		m, err = parseJSONConfig(data)
+	} else {
+		m, err = parseINIConfig(data)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: %v", fileName, err)
+	}
+
+	config.Lock()
+	config.fileName = fileName
+	config.file = m
+	config.Unlock()
+
+	return nil
+}
+
+// UnloadConfigFile #
+func UnloadConfigFile() {
+	config.Lock()
+	config.fileName = ""
+	config.file = nil
+	config.Unlock()
+}
+
+// ConfigFileName #zuletzt geladene Konfigurationsdatei
+func ConfigFileName() string {
+	config.RLock()
+	defer config.RUnlock()
+	return config.fileName
+}
+
+// ParamLookup #Kommandozeile, Umgebung, Konfigurationsdatei; ok = false wenn nirgends vorhanden
+func ParamLookup(sKey string) (v string, src ParamSource, ok bool) {
+	return DefaultParams().Lookup(sKey)
+}
+
+// configLookup #Umgebung und Konfigurationsdatei
+func configLookup(lKey string) (v string, src ParamSource, ok bool) {
+	if env := ParamEnvName(lKey); len(env) > 0 {
+		if v, ok = os.LookupEnv(env); ok {
+			return v, SourceEnv, true
+		}
+	}
+
+	config.RLock()
+	v, ok = config.file[lKey]
+	config.RUnlock()
+	if ok {
+		return v, SourceFile, true
+	}
+
+	return "", SourceDefault, false
+}
+
+// ParamSourceOf #woher kommt der Wert, origin: Parameter, Umgebungsvariable bzw. Datei
+func ParamSourceOf(sKey string) (src ParamSource, origin string) {
+	_, src, _ = ParamLookup(sKey)
+
+	switch src {
+	case SourceArg:
+		origin = "-" + strings.ToLower(sKey)
+	case SourceEnv:
+		origin = ParamEnvName(strings.ToLower(sKey))
+	case SourceFile:
+		origin = ConfigFileName()
+	}
+
+	return
+}
+
+func parseINIConfig(data []byte) (map[string]string, error) {
+	m := make(map[string]string)
+	section := ""
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	line := 0
+	for sc.Scan() {
+		line++
+		s := strings.TrimSpace(sc.Text())
+		if len(s) == 0 || s[0] == ';' || s[0] == '#' {
+			continue
+		}
+
+		if s[0] == '[' && s[len(s)-1] == ']' {
+			section = strings.ToLower(strings.TrimSpace(s[1 : len(s)-1]))
+			continue
+		}
+
+		ix := strings.Index(s, "=")
+		if ix < 1 {
+			return nil, fmt.Errorf("line %d: key=value expected", line)
+		}
+
+		key := strings.ToLower(strings.TrimSpace(s[:ix]))
+		if len(section) > 0 {
+			key = section + "." + key
+		}
+
+		val := strings.TrimSpace(s[ix+1:])
+		if len(val) > 1 && (val[0] == '"' || val[0] == '\'') && val[len(val)-1] == val[0] {
+			val = val[1 : len(val)-1]
+		}
+
+		m[key] = val
+	}
+
+	return m, sc.Err()
+}
+
+func parseJSONConfig(data []byte) (map[string]string, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]string)
+	flattenJSON("", raw, m)
+	return m, nil
+}
+
+// flattenJSON #{"db":{"host":"x"}} ergibt db.host
+func flattenJSON(pfx string, raw map[string]interface{}, m map[string]string) {
+	for k, v := range raw {
+		key := strings.ToLower(pfx + k)
+
+		switch x := v.(type) {
+		case map[string]interface{}:
+			flattenJSON(key+".", x, m)
+		case string:
+			m[key] = x
+		case nil:
+			m[key] = ""
+		case float64:
+			m[key] = strconv.FormatFloat(x, 'f', -1, 64)
+		default:
+			b, _ := json.Marshal(x)
+			m[key] = string(b)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xDuration.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DurationStyle #Ausgabeformat fuer FormatDuration
+type DurationStyle int
+
+const (
+	// DurationClock #"02:03:04", ab 24 Stunden "1d 02:03:04"
+	DurationClock DurationStyle = iota
+	// DurationUnits #"26h03m", "3m04s", "4s"
+	DurationUnits
+	// DurationGerman #"1 Tg. 2 Std. 3 Min.", "4 Sek."
+	DurationGerman
+)
+
+const durationDay = 24 * time.Hour
+
+// FormatDuration #ms: Millisekunden ausgeben, sonst wird auf Sekunden abgeschnitten
+func FormatDuration(d time.Duration, style DurationStyle, ms bool) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	d = d.Truncate(time.Millisecond)
+	if !ms {
+		d = d.Truncate(time.Second)
+	}
+
+	// -500ms ohne ms ist "00:00:00", nicht "-00:00:00"
+	if d == 0 {
+		sign = ""
+	}
+
+	days := int64(d / durationDay)
+	hh := int64(d / time.Hour)
+	mm := int64(d/time.Minute) % 60
+	ss := int64(d/time.Second) % 60
+	ns := int64(d/time.Millisecond) % 1000
+
+	switch style {
+	case DurationUnits:
+		return sign + formatUnits(hh, mm, ss, ns, ms)
+
+	case DurationGerman:
+		var p []string
+		add := func(v int64, unit string) {
+			if v != 0 {
+				p = append(p, strconv.FormatInt(v, 10)+" "+unit)
+			}
+		}
+		add(days, "Tg.")
+		add(hh%24, "Std.")
+		add(mm, "Min.")
+		add(ss, "Sek.")
+		add(ns, "ms")
+
+		if len(p) == 0 {
+			return "0 Sek."
+		}
+		return sign + strings.Join(p, " ")
+	}
+
+	s := fmt.Sprintf("%02d:%02d:%02d", hh%24, mm, ss)
+	if ms {
+		s += fmt.Sprintf(".%03d", ns)
+	}
+
+	if days > 0 {
+		s = strconv.FormatInt(days, 10) + "d " + s
+	}
+
+	return sign + s
+}
+
+// formatUnits #fuehrende und abschliessende Null-Einheiten entfallen
+func formatUnits(hh, mm, ss, ns int64, ms bool) string {
+	v := []int64{hh, mm, ss}
+	u := []string{"h", "m", "s"}
+
+	first, last := 0, 2
+	for first < 2 && v[first] == 0 {
+		first++
+	}
+	for last > first && v[last] == 0 && !(last == 2 && ms && ns > 0) {
+		last--
+	}
+
+	var sb strings.Builder
+	for i := first; i <= last; i++ {
+		if i == first {
+			sb.WriteString(strconv.FormatInt(v[i], 10))
+		} else {
+			fmt.Fprintf(&sb, "%02d", v[i])
+		}
+
+		if i == 2 && ms && ns > 0 {
+			fmt.Fprintf(&sb, ".%03d", ns)
+		}
+		sb.WriteString(u[i])
+	}
+
+	return sb.String()
+}
+
+var durationUnits = map[string]time.Duration{
+	"d": durationDay, "tg": durationDay, "tag": durationDay, "tage": durationDay, "day": durationDay, "days": durationDay,
+	"h": time.Hour, "std": time.Hour, "stunde": time.Hour, "stunden": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"m": time.Minute, "min": time.Minute, "minute": time.Minute, "minuten": time.Minute, "minutes": time.Minute,
+	"s": time.Second, "sek": time.Second, "sec": time.Second, "sekunde": time.Second, "sekunden": time.Second,
+	"second": time.Second, "seconds": time.Second,
+	"ms": time.Millisecond, "us": time.Microsecond, "µs": time.Microsecond, "ns": time.Nanosecond,
+}
+
+// ParseDuration #Gegenstueck zu FormatDuration und STimeDif, auch Go-Syntax ("1h30m") und "1,5 Std."
+func ParseDuration(s string) (time.Duration, error) {
+	in := s
+	s = strings.TrimSpace(s)
+
+	neg := false
+	if strings.HasPrefix(s, "-") {
+		neg = true
+		s = strings.TrimSpace(s[1:])
+	} else if strings.HasPrefix(s, "+") {
+		s = strings.TrimSpace(s[1:])
+	}
+
+	if len(s) == 0 {
+		return 0, fmt.Errorf("invalid duration %q", in)
+	}
+
+	var d time.Duration
+	var err error
+
+	// "1d 02:03:04", "26:03:04", "02:03:04.250", "02:03"
+	if ix := strings.LastIndexAny(s, " \t"); strings.Contains(s, ":") {
+		clock := s[ix+1:]
+		if d, err = parseClockDuration(clock); err == nil && ix > 0 {
+			var x time.Duration
+			if x, err = parseUnitDuration(strings.TrimSpace(s[:ix])); err == nil && x > math.MaxInt64-d {
+				err = errDurationRange
+			}
+			d += x
+		}
+	} else {
+		d, err = parseUnitDuration(s)
+	}
+
+	if err == errDurationRange {
+		return 0, fmt.Errorf("duration %q out of range", in)
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", in)
+	}
+
+	if neg {
+		d = -d
+	}
+
+	return d, nil
+}
+
+var errDurationRange = fmt.Errorf("duration out of range")
+
+// parseClockDuration #hh:mm[:ss[.fff]], Stunden bis zur Grenze von time.Duration
+func parseClockDuration(s string) (time.Duration, error) {
+	p := strings.Split(s, ":")
+	if len(p) < 2 || len(p) > 3 {
+		return 0, fmt.Errorf("hh:mm:ss expected")
+	}
+
+	hh, err := strconv.ParseUint(p[0], 10, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	if hh >= uint64(math.MaxInt64/int64(time.Hour)) {
+		return 0, errDurationRange
+	}
+
+	mm, err := strconv.ParseUint(p[1], 10, 8)
+	if err != nil || mm > 59 {
+		return 0, fmt.Errorf("invalid minutes")
+	}
+
+	d := time.Duration(hh)*time.Hour + time.Duration(mm)*time.Minute
+	if len(p) == 3 {
+		ss, err := strconv.ParseFloat(strings.Replace(p[2], ",", ".", 1), 64)
+		if err != nil || ss < 0 || ss >= 60 || p[2][0] < '0' || p[2][0] > '9' {
+			return 0, fmt.Errorf("invalid seconds")
+		}
+		d += time.Duration(ss * float64(time.Second)).Round(time.Microsecond)
+	}
+
+	return d, nil
+}
+
+// parseUnitDuration #Folge aus Zahl und Einheit: "1d2h", "26h03m", "2 Std. 3 Min.", "1,5 Tage"
+func parseUnitDuration(s string) (time.Duration, error) {
+	var d time.Duration
+	for len(s) > 0 {
+		i := 0
+		for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.' || s[i] == ',') {
+			i++
+		}
+		if i == 0 {
+			return 0, fmt.Errorf("number expected")
+		}
+
+		v, err := strconv.ParseFloat(strings.Replace(s[:i], ",", ".", 1), 64)
+		if err != nil {
+			return 0, err
+		}
+
+		s = strings.TrimLeft(s[i:], " \t")
+
+		i = 0
+		for i < len(s) && !(s[i] >= '0' && s[i] <= '9') && s[i] != ' ' && s[i] != '\t' {
+			i++
+		}
+
+		unit, ok := durationUnits[strings.TrimSuffix(strings.ToLower(s[:i]), ".")]
+		if !ok {
+			return 0, fmt.Errorf("unknown unit %q", s[:i])
+		}
+
+		// time.Duration laeuft sonst still ueber
+		x := v * float64(unit)
+		if x >= math.MaxInt64-float64(d) {
+			return 0, errDurationRange
+		}
+
+		d += time.Duration(x)
+		s = strings.TrimLeft(s[i:], " \t")
+	}
+
+	return d, nil
+}
@@ -0,0 +1,195 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xExit.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// FatalMode #Verhalten von Fatal/FatalF und FatalCode/FatalCodeF
+type FatalMode int
+
+const (
+	// FatalExit #ExitHooks ausfuehren, dann ExitFunc(code)
+	FatalExit FatalMode = iota
+	// FatalPanic #panic mit *FatalError
+	FatalPanic
+	// FatalReturn #nur FatalCode/FatalCodeF loggen und liefern *FatalError, Fatal/FatalF beenden wie FatalExit
+	FatalReturn
+)
+
+// FatalError #
+type FatalError struct {
+	Code int
+	Msg  string
+}
+
+func (e *FatalError) Error() string {
+	return fmt.Sprintf("fatal(%d): %s", e.Code, e.Msg)
+}
+
+// ExitFunc #wird von Exit aufgerufen, in Tests austauschbar
+var ExitFunc = os.Exit
+
+// ExitHookTimeout #maximale Laufzeit aller ExitHooks zusammen
+var ExitHookTimeout = 10 * time.Second
+
+type exitHook struct {
+	id   int
+	name string
+	fn   func()
+}
+
+var exitState struct {
+	sync.Mutex
+	hooks   []exitHook
+	nextID  int
+	mode    FatalMode
+	running bool
+}
+
+// SetFatalMode #
+func SetFatalMode(m FatalMode) {
+	exitState.Lock()
+	exitState.mode = m
+	exitState.Unlock()
+}
+
+// GetFatalMode #
+func GetFatalMode() FatalMode {
+	exitState.Lock()
+	defer exitState.Unlock()
+	return exitState.mode
+}
+
+// AddExitHook #fn wird vor dem Beenden ausgefuehrt (umgekehrte Reihenfolge wie defer)
+func AddExitHook(name string, fn func()) int {
+	exitState.Lock()
+	defer exitState.Unlock()
+
+	exitState.nextID++
+	exitState.hooks = append(exitState.hooks, exitHook{id: exitState.nextID, name: name, fn: fn})
+	return exitState.nextID
+}
+
+// RemoveExitHook #
+func RemoveExitHook(id int) {
+	exitState.Lock()
+	defer exitState.Unlock()
+
+	for i, h := range exitState.hooks {
+		if h.id == id {
+			exitState.hooks = append(exitState.hooks[:i], exitState.hooks[i+1:]...)
+			return
+		}
+	}
+}
+
+// RunExitHooks #fuehrt alle Hooks einmalig aus, liefert false bei Timeout
+func RunExitHooks() bool {
+	exitState.Lock()
+	if exitState.running {
+		exitState.Unlock()
+		return true
+	}
+	exitState.running = true
+	hooks := exitState.hooks
+	exitState.hooks = nil
+	exitState.Unlock()
+
+	defer func() {
+		exitState.Lock()
+		exitState.running = false
+		exitState.Unlock()
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := len(hooks) - 1; i >= 0; i-- {
+			runExitHook(hooks[i])
+		}
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(ExitHookTimeout):
+		PrintStdErr("\nExitHooks: timeout after %v\n", ExitHookTimeout)
+		return false
+	}
+}
+
+func runExitHook(h exitHook) {
+	defer func() {
+		if r := recover(); r != nil {
+			PrintStdErr("\nExitHook %s: panic: %v\n", h.name, r)
+		}
+	}()
+
+	h.fn()
+}
+
+// Exit #ExitHooks ausfuehren und mit code beenden
+func Exit(code int) {
+	RunExitHooks()
+	flushLoggers()
+	ExitFunc(code)
+}
+
+// FatalCode #wie Fatal, aber mit Exit-Code; liefert *FatalError im Modus FatalReturn
+func FatalCode(code int, v ...interface{}) error {
+	return fatalCode(code, true, v...)
+}
+
+// FatalCodeF #wie FatalF, aber mit Exit-Code; liefert *FatalError im Modus FatalReturn
+func FatalCodeF(code int, format string, v ...interface{}) error {
+	return fatalCodeF(code, true, format, v...)
+}
+
+func fatalCode(code int, canReturn bool, v ...interface{}) error {
+	r := defaultLog.record(LevelFatal, fmt.Sprint(v...))
+	fmt.Printf("\n%s%s\n", r.STime, r.Msg)
+
+	// stdout statt stderr
+	defaultLog.emit(r, SinkStderr)
+
+	return fatalExit(code, r.Msg, canReturn)
+}
+
+func fatalCodeF(code int, canReturn bool, format string, v ...interface{}) error {
+	s := fmt.Sprintf(format, v...)
+
+	defaultLog.logx(LevelFatal, format, s)
+
+	// FatalError wie das Log nur geschwaerzt
+	return fatalExit(code, defaultLog.record(LevelFatal, s).Msg, canReturn)
+}
+
+// fatalExit #canReturn: nur FatalCode/FatalCodeF, deren Aufrufer den Fehler auswerten
+func fatalExit(code int, s string, canReturn bool) error {
+	e := &FatalError{Code: code, Msg: s}
+
+	switch GetFatalMode() {
+	case FatalPanic:
+		panic(e)
+	case FatalReturn:
+		if canReturn {
+			return e
+		}
+	}
+
+	Exit(code)
+	return e
+}
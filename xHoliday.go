@@ -0,0 +1,284 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xHoliday.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Token fuer PermitWeekDay und Schedule: "ho" schliesst Feiertage ein, "!ho" aus
+const holidayToken = "ho"
+
+// Holiday #
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// holidayRule #fester Tag (month > 0) oder Abstand zu Ostersonntag, from/to: Jahre, 0 = ohne Grenze
+type holidayRule struct {
+	name   string
+	month  time.Month
+	day    int
+	easter int
+	bubt   bool // Buss- und Bettag: Mittwoch vor dem 23.11.
+	states string
+	from   int
+	to     int
+}
+
+// gesetzliche Feiertage in Deutschland, states leer = bundesweit
+var germanHolidays = []holidayRule{
+	{name: "Neujahr", month: 1, day: 1},
+	{name: "Heilige Drei Könige", month: 1, day: 6, states: "BW,BY,ST"},
+	{name: "Internationaler Frauentag", month: 3, day: 8, states: "BE", from: 2019},
+	{name: "Internationaler Frauentag", month: 3, day: 8, states: "MV", from: 2023},
+	{name: "Karfreitag", easter: -2},
+	{name: "Ostersonntag", easter: 0, states: "BB"},
+	{name: "Ostermontag", easter: 1},
+	{name: "Tag der Arbeit", month: 5, day: 1},
+	{name: "Christi Himmelfahrt", easter: 39},
+	{name: "Pfingstsonntag", easter: 49, states: "BB"},
+	{name: "Pfingstmontag", easter: 50},
+	{name: "Fronleichnam", easter: 60, states: "BW,BY,HE,NW,RP,SL"},
+	{name: "Mariä Himmelfahrt", month: 8, day: 15, states: "SL"},
+	{name: "Weltkindertag", month: 9, day: 20, states: "TH", from: 2019},
+	{name: "Tag der Deutschen Einheit", month: 10, day: 3, from: 1990},
+	{name: "Reformationstag", month: 10, day: 31, states: "BB,MV,SN,ST,TH"},
+	{name: "Reformationstag", month: 10, day: 31, states: "HB,HH,NI,SH", from: 2018},
+	{name: "Reformationstag", month: 10, day: 31, states: "BE,BW,BY,HB,HE,HH,NI,NW,RP,SH,SL", from: 2017, to: 2017},
+	{name: "Allerheiligen", month: 11, day: 1, states: "BW,BY,NW,RP,SL"},
+	{name: "Buß- und Bettag", bubt: true, states: "SN"},
+	{name: "1. Weihnachtstag", month: 12, day: 25},
+	{name: "2. Weihnachtstag", month: 12, day: 26},
+}
+
+// HolidayStates #Kuerzel der Bundeslaender fuer NewHolidayCalendar
+var HolidayStates = []string{"BB", "BE", "BW", "BY", "HB", "HE", "HH", "MV", "NI", "NW", "RP", "SH", "SL", "SN", "ST", "TH"}
+
+// HolidayCalendar #gesetzliche Feiertage eines Bundeslandes und eigene Tage
+type HolidayCalendar struct {
+	mu     sync.Mutex
+	state  string
+	rules  []holidayRule
+	custom map[string]string // "yyyymmdd" bzw. "mmdd" (jaehrlich) -> Name
+	years  map[int]map[string]string
+}
+
+var holidays = struct {
+	sync.Mutex
+	c *HolidayCalendar
+}{c: NewHolidayCalendar("")}
+
+// NewHolidayCalendar #state z.B. "NW", leer = nur bundesweite Feiertage
+func NewHolidayCalendar(state string) *HolidayCalendar {
+	state = strings.ToUpper(state)
+
+	c := &HolidayCalendar{state: state, custom: make(map[string]string)}
+	for _, r := range germanHolidays {
+		if len(r.states) == 0 || (len(state) > 0 && strings.Contains(","+r.states+",", ","+state+",")) {
+			c.rules = append(c.rules, r)
+		}
+	}
+
+	return c
+}
+
+// SetHolidayCalendar #Kalender fuer IsHoliday, PermitWeekDay ("ho") und Schedule, nil = keine Feiertage
+func SetHolidayCalendar(c *HolidayCalendar) {
+	holidays.Lock()
+	holidays.c = c
+	holidays.Unlock()
+}
+
+// GetHolidayCalendar #
+func GetHolidayCalendar() *HolidayCalendar {
+	holidays.Lock()
+	defer holidays.Unlock()
+	return holidays.c
+}
+
+// IsHoliday #Tag von t ist Feiertag im eingestellten Kalender
+func IsHoliday(t time.Time) bool {
+	c := GetHolidayCalendar()
+	if c == nil {
+		return false
+	}
+
+	_, ok := c.Holiday(t)
+	return ok
+}
+
+// Easter #Ostersonntag (gregorianisch)
+func Easter(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// State #
+func (c *HolidayCalendar) State() string {
+	return c.state
+}
+
+// Add #einzelner Tag, z.B. Brueckentag
+func (c *HolidayCalendar) Add(d time.Time, name string) {
+	c.mu.Lock()
+	c.custom[d.Format("20060102")] = name
+	c.years = nil
+	c.mu.Unlock()
+}
+
+// AddYearly #jedes Jahr am selben Tag, z.B. Heiligabend
+func (c *HolidayCalendar) AddYearly(month time.Month, day int, name string) {
+	c.mu.Lock()
+	c.custom[fmt.Sprintf("%02d%02d", month, day)] = name
+	c.years = nil
+	c.mu.Unlock()
+}
+
+// LoadFile #je Zeile "YYYY-MM-DD Name", "DD.MM.YYYY Name" oder jaehrlich "MM-DD Name", "DD.MM. Name";
+// Leerzeilen und Zeilen mit # bzw. ; werden uebergangen
+func (c *HolidayCalendar) LoadFile(fileName string) error {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		l := strings.TrimSpace(sc.Text())
+		if len(l) == 0 || l[0] == '#' || l[0] == ';' {
+			continue
+		}
+
+		d := l
+		name := ""
+		if ix := strings.IndexAny(l, " \t"); ix > 0 {
+			d, name = l[:ix], strings.TrimSpace(l[ix+1:])
+		}
+
+		var y, m, day int
+		switch {
+		case len(d) == 10 && d[4] == '-':
+			_, err = fmt.Sscanf(d, "%04d-%02d-%02d", &y, &m, &day)
+		case len(d) == 10 && d[2] == '.':
+			_, err = fmt.Sscanf(d, "%02d.%02d.%04d", &day, &m, &y)
+		case len(d) == 5 && d[2] == '-':
+			_, err = fmt.Sscanf(d, "%02d-%02d", &m, &day)
+		case len(d) == 6 && d[2] == '.' && d[5] == '.':
+			_, err = fmt.Sscanf(d, "%02d.%02d.", &day, &m)
+		default:
+			err = fmt.Errorf("invalid date")
+		}
+
+		if err != nil || m < 1 || m > 12 || day < 1 || day > 31 {
+			return fmt.Errorf("%s:%d: invalid date %q", fileName, n, d)
+		}
+
+		if y == 0 {
+			c.AddYearly(time.Month(m), day, name)
+		} else {
+			c.Add(time.Date(y, time.Month(m), day, 0, 0, 0, 0, time.UTC), name)
+		}
+	}
+
+	return sc.Err()
+}
+
+// year #"mmdd" -> Name, berechnet beim ersten Zugriff
+func (c *HolidayCalendar) year(y int) map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if m, ok := c.years[y]; ok {
+		return m
+	}
+
+	m := make(map[string]string)
+	add := func(d time.Time, name string) {
+		k := d.Format("0102")
+		if _, ok := m[k]; !ok {
+			m[k] = name
+		}
+	}
+
+	easter := Easter(y)
+	for _, r := range c.rules {
+		if (r.from > 0 && y < r.from) || (r.to > 0 && y > r.to) {
+			continue
+		}
+
+		switch {
+		case r.bubt:
+			d := time.Date(y, 11, 22, 0, 0, 0, 0, time.UTC)
+			add(d.AddDate(0, 0, -((int(d.Weekday())-int(time.Wednesday)+7)%7)), r.name)
+		case r.month > 0:
+			add(time.Date(y, r.month, r.day, 0, 0, 0, 0, time.UTC), r.name)
+		default:
+			add(easter.AddDate(0, 0, r.easter), r.name)
+		}
+	}
+
+	ys := fmt.Sprintf("%04d", y)
+	for k, name := range c.custom {
+		if len(k) == 4 {
+			m[k] = name
+		} else if k[0:4] == ys {
+			m[k[4:]] = name
+		}
+	}
+
+	if c.years == nil {
+		c.years = make(map[int]map[string]string)
+	}
+	c.years[y] = m
+
+	return m
+}
+
+// Holiday #Name des Feiertags am Tag von t (Zeitzone von t)
+func (c *HolidayCalendar) Holiday(t time.Time) (string, bool) {
+	name, ok := c.year(t.Year())[t.Format("0102")]
+	return name, ok
+}
+
+// Holidays #alle Feiertage eines Jahres, nach Datum sortiert
+func (c *HolidayCalendar) Holidays(year int) []Holiday {
+	var h []Holiday
+	for k, name := range c.year(year) {
+		h = append(h, Holiday{Date: time.Date(year, time.Month(Esubstr2int(k, 0, 2)), Esubstr2int(k, 2, 2), 0, 0, 0, 0, time.UTC), Name: name})
+	}
+
+	sort.Slice(h, func(i, j int) bool { return h[i].Date.Before(h[j].Date) })
+	return h
+}
@@ -0,0 +1,426 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xJob.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// OverlapPolicy #was passiert, wenn ein Job beim naechsten Termin noch laeuft
+type OverlapPolicy int
+
+const (
+	// OverlapSkip #Termin wird ausgelassen
+	OverlapSkip OverlapPolicy = iota
+	// OverlapQueue #Termin wird nach dem laufenden Durchgang nachgeholt
+	OverlapQueue
+)
+
+// ErrJobLocked #LockFile ist von einem anderen Prozess belegt
+var ErrJobLocked = errors.New("job locked")
+
+// Job #
+type Job struct {
+	Name     string
+	Schedule *Schedule
+	Run      func(ctx context.Context) error
+	Overlap  OverlapPolicy
+	LockFile string        // leer = ohne; sonst exklusiv angelegt (mit PID), nur ein Prozess je Rechner
+	LockAge  time.Duration // aelter gilt die Lock-Datei als verwaist, 0 = nur PID pruefen
+	Timeout  time.Duration // 0 = ohne
+
+	mu      sync.Mutex
+	running bool
+	queued  int
+	status  JobStatus
+}
+
+// JobStatus #
+type JobStatus struct {
+	Name      string
+	Schedule  string
+	Running   bool
+	Runs      int
+	Skipped   int
+	LastStart time.Time
+	LastEnd   time.Time
+	LastErr   error
+	Next      time.Time
+}
+
+// JobRunner #fuehrt Jobs nach ihrem Schedule in eigenen Goroutinen aus
+type JobRunner struct {
+	mu       sync.Mutex
+	ctl      sync.Mutex // Start und Stop nacheinander
+	jobs     []*Job
+	log      *Logger
+	ctx      context.Context
+	stop     context.CancelFunc
+	stopping bool // Stop wartet, keine neuen Durchgaenge
+	wg       sync.WaitGroup
+}
+
+// NewJobRunner #Meldungen gehen an den DefaultLogger
+func NewJobRunner() *JobRunner {
+	return &JobRunner{log: defaultLog}
+}
+
+// SetLogger #
+func (r *JobRunner) SetLogger(l *Logger) {
+	r.mu.Lock()
+	r.log = l
+	r.mu.Unlock()
+}
+
+// Add #kann auch nach Start aufgerufen werden
+func (r *JobRunner) Add(j *Job) error {
+	if len(j.Name) == 0 || j.Schedule == nil || j.Run == nil {
+		return errors.New("job: Name, Schedule and Run required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, x := range r.jobs {
+		if x.Name == j.Name {
+			return fmt.Errorf("job %s: already registered", j.Name)
+		}
+	}
+
+	r.jobs = append(r.jobs, j)
+	if r.ctx != nil {
+		r.wg.Add(1)
+		go r.loop(r.ctx, j)
+	}
+
+	return nil
+}
+
+// AddFunc #Job mit Schedule-Ausdruck und OverlapSkip
+func (r *JobRunner) AddFunc(name string, expr string, fn func(ctx context.Context) error) (*Job, error) {
+	s, err := ParseSchedule(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &Job{Name: name, Schedule: s, Run: fn}
+	return j, r.Add(j)
+}
+
+// Start #ctx beendet alle Jobs wie Stop
+func (r *JobRunner) Start(ctx context.Context) {
+	r.ctl.Lock()
+	defer r.ctl.Unlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.ctx != nil {
+		return
+	}
+
+	r.ctx, r.stop = context.WithCancel(ctx)
+	for _, j := range r.jobs {
+		r.wg.Add(1)
+		go r.loop(r.ctx, j)
+	}
+}
+
+// Stop #bricht laufende Jobs ueber ihren Context ab und wartet auf deren Ende;
+// RunNow waehrend Stop wird ausgelassen
+func (r *JobRunner) Stop() {
+	r.ctl.Lock()
+	defer r.ctl.Unlock()
+
+	r.mu.Lock()
+	stop := r.stop
+	r.ctx, r.stop = nil, nil
+	r.stopping = true
+	r.mu.Unlock()
+
+	if stop != nil {
+		stop()
+	}
+	r.wg.Wait()
+
+	r.mu.Lock()
+	r.stopping = false
+	r.mu.Unlock()
+}
+
+// RunNow #Job sofort ausfuehren, OverlapPolicy gilt
+func (r *JobRunner) RunNow(name string) error {
+	j := r.job(name)
+	if j == nil {
+		return fmt.Errorf("job %s: not found", name)
+	}
+
+	r.mu.Lock()
+	ctx := r.ctx
+	r.mu.Unlock()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	r.trigger(ctx, j)
+	return nil
+}
+
+// Status #nach Namen sortiert
+func (r *JobRunner) Status() []JobStatus {
+	r.mu.Lock()
+	jobs := append([]*Job(nil), r.jobs...)
+	r.mu.Unlock()
+
+	st := make([]JobStatus, 0, len(jobs))
+	now := Now()
+	for _, j := range jobs {
+		j.mu.Lock()
+		s := j.status
+		s.Name = j.Name
+		s.Schedule = j.Schedule.String()
+		s.Running = j.running
+		j.mu.Unlock()
+
+		s.Next = j.Schedule.Next(now)
+		st = append(st, s)
+	}
+
+	sort.Slice(st, func(i, k int) bool { return st[i].Name < st[k].Name })
+	return st
+}
+
+func (r *JobRunner) job(name string) *Job {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, j := range r.jobs {
+		if j.Name == name {
+			return j
+		}
+	}
+
+	return nil
+}
+
+func (r *JobRunner) logger() *Logger {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.log
+}
+
+// loop #wartet bis zum naechsten Termin
+func (r *JobRunner) loop(ctx context.Context, j *Job) {
+	defer r.wg.Done()
+
+	// last #zuletzt ausgeloester Termin, geht die Uhr nach, laeuft er nicht ein zweites Mal
+	var last time.Time
+	for {
+		now := Now()
+		from := now
+		if from.Before(last) {
+			from = last
+		}
+
+		next := j.Schedule.Next(from)
+		if next.IsZero() {
+			r.logger().WarnF("job %s: no further run for schedule %q", j.Name, j.Schedule)
+			return
+		}
+
+		tm := NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			tm.Stop()
+			return
+		case <-tm.C():
+		}
+
+		last = next
+		r.trigger(ctx, j)
+	}
+}
+
+// trigger #startet den Job, falls er nicht bereits laeuft
+func (r *JobRunner) trigger(ctx context.Context, j *Job) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.running {
+		if j.Overlap == OverlapQueue {
+			j.queued++
+			return
+		}
+
+		j.status.Skipped++
+		r.logger().WarnF("job %s: skipped, previous run still active", j.Name)
+		return
+	}
+
+	// wg.Add nur, solange Stop nicht wartet
+	r.mu.Lock()
+	if r.stopping {
+		r.mu.Unlock()
+		j.status.Skipped++
+		r.logger().WarnF("job %s: skipped, runner stopping", j.Name)
+		return
+	}
+	r.wg.Add(1)
+	r.mu.Unlock()
+
+	j.running = true
+	go func() {
+		defer r.wg.Done()
+
+		for {
+			r.execute(ctx, j)
+
+			j.mu.Lock()
+			if j.queued == 0 || ctx.Err() != nil {
+				j.queued = 0
+				j.running = false
+				j.mu.Unlock()
+				return
+			}
+			j.queued--
+			j.mu.Unlock()
+		}
+	}()
+}
+
+// execute #ein Durchgang mit Lock-Datei, Timeout und Log von Start, Ende und Dauer
+func (r *JobRunner) execute(ctx context.Context, j *Job) {
+	log := r.logger()
+
+	if len(j.LockFile) > 0 {
+		unlock, err := lockFile(j.LockFile, j.LockAge)
+		if err != nil {
+			j.mu.Lock()
+			j.status.Skipped++
+			j.mu.Unlock()
+
+			log.WarnF("job %s: skipped, %v", j.Name, err)
+			return
+		}
+		defer unlock()
+	}
+
+	if j.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, j.Timeout)
+		defer cancel()
+	}
+
+	tA := Now()
+	j.mu.Lock()
+	j.status.Runs++
+	j.status.LastStart = tA
+	j.mu.Unlock()
+
+	log.LogF("job %s: start", j.Name)
+
+	err := runJob(ctx, j)
+
+	tL := Now()
+	j.mu.Lock()
+	j.status.LastEnd = tL
+	j.status.LastErr = err
+	j.mu.Unlock()
+
+	if err != nil {
+		log.ErrorF("job %s: failed after %s: %v", j.Name, STimeDif(tA, tL), err)
+		return
+	}
+
+	log.LogF("job %s: end, duration %s", j.Name, STimeDif(tA, tL))
+}
+
+// runJob #panic wird zum Fehler
+func runJob(ctx context.Context, j *Job) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+
+	return j.Run(ctx)
+}
+
+// lockFile #legt fileName exklusiv an (mit PID), unlock entfernt sie wieder, ebenso ein ExitHook;
+// eine Lock-Datei, deren Prozess nicht mehr laeuft oder die aelter als maxAge ist, wird uebernommen
+func lockFile(fileName string, maxAge time.Duration) (unlock func(), err error) {
+	f, err := os.OpenFile(fileName, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil && os.IsExist(err) && removeStale(fileName, func() bool { return staleLock(fileName, maxAge) }) {
+		f, err = os.OpenFile(fileName, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	}
+
+	if err != nil {
+		if os.IsExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrJobLocked, fileName)
+		}
+		return nil, err
+	}
+
+	f.WriteString(strconv.Itoa(os.Getpid()))
+	f.Close()
+
+	id := AddExitHook("lock "+fileName, func() { os.Remove(fileName) })
+	return func() {
+		RemoveExitHook(id)
+		os.Remove(fileName)
+	}, nil
+}
+
+// staleLock #Prozess der Lock-Datei beendet bzw. Datei aelter als maxAge
+func staleLock(fileName string, maxAge time.Duration) bool {
+	fi, err := os.Stat(fileName)
+	if err != nil {
+		return false
+	}
+
+	if maxAge > 0 && time.Since(fi.ModTime()) > maxAge {
+		return true
+	}
+
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return false
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return false
+	}
+
+	return !processAlive(pid)
+}
+
+// processAlive #Signal 0; wo das nicht geht (Windows), genuegt FindProcess
+func processAlive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+
+	err = p.Signal(syscall.Signal(0))
+	return err == nil || !errors.Is(err, os.ErrProcessDone)
+}
@@ -0,0 +1,262 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xLocale.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Locale #Namen von Wochentagen (Index time.Weekday, 0 = Sonntag) und Monaten (0 = Januar)
+type Locale struct {
+	Name        string
+	Days        [7]string
+	DaysShort   [7]string
+	DayTokens   [7][]string // weitere Kuerzel fuer PermitWeekDay und Schedule
+	Months      [12]string
+	MonthsShort [12]string
+}
+
+var locales = struct {
+	sync.Mutex
+	m      map[string]*Locale
+	order  []string
+	def    string
+	days   map[string]time.Weekday
+	months map[string]time.Month
+}{m: make(map[string]*Locale), def: "de"}
+
+func init() {
+	RegisterLocale(&Locale{
+		Name:        "de",
+		Days:        [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		DaysShort:   [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		Months:      [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		MonthsShort: [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+	})
+
+	RegisterLocale(&Locale{
+		Name:        "en",
+		Days:        [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		DaysShort:   [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		Months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		MonthsShort: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	})
+
+	RegisterLocale(&Locale{
+		Name:      "pl",
+		Days:      [7]string{"niedziela", "poniedziałek", "wtorek", "środa", "czwartek", "piątek", "sobota"},
+		DaysShort: [7]string{"nd", "pn", "wt", "śr", "cz", "pt", "sb"},
+		DayTokens: [7][]string{{"nie"}, {"pon"}, {"wto"}, {"sr", "śro", "sro"}, {"czw"}, {"pią", "pia"}, {"sob"}},
+		Months: [12]string{"styczeń", "luty", "marzec", "kwiecień", "maj", "czerwiec",
+			"lipiec", "sierpień", "wrzesień", "październik", "listopad", "grudzień"},
+		MonthsShort: [12]string{"sty", "lut", "mar", "kwi", "maj", "cze", "lip", "sie", "wrz", "paź", "lis", "gru"},
+	})
+}
+
+// RegisterLocale #ersetzt eine Locale gleichen Namens, l wird kopiert
+func RegisterLocale(l *Locale) {
+	locales.Lock()
+	defer locales.Unlock()
+
+	if _, ok := locales.m[l.Name]; !ok {
+		locales.order = append(locales.order, l.Name)
+	}
+	locales.m[l.Name] = l.clone()
+	rebuildLocaleTokens()
+}
+
+// GetLocale #Kopie, Aenderungen nur ueber RegisterLocale; nil wenn unbekannt
+func GetLocale(name string) *Locale {
+	locales.Lock()
+	defer locales.Unlock()
+	return locales.m[name].clone()
+}
+
+func (l *Locale) clone() *Locale {
+	if l == nil {
+		return nil
+	}
+
+	c := *l
+	for i, t := range l.DayTokens {
+		c.DayTokens[i] = append([]string(nil), t...)
+	}
+	return &c
+}
+
+// Locales #Namen in Reihenfolge der Registrierung
+func Locales() []string {
+	locales.Lock()
+	defer locales.Unlock()
+	return append([]string(nil), locales.order...)
+}
+
+// SetDefaultLocale #fuer FormatDate; ihre Kuerzel haben bei Mehrdeutigkeit Vorrang
+func SetDefaultLocale(name string) error {
+	locales.Lock()
+	defer locales.Unlock()
+
+	if _, ok := locales.m[name]; !ok {
+		return fmt.Errorf("unknown locale: %s", name)
+	}
+
+	locales.def = name
+	rebuildLocaleTokens()
+	return nil
+}
+
+// DefaultLocale #Kopie wie GetLocale
+func DefaultLocale() *Locale {
+	return defaultLocale().clone()
+}
+
+// defaultLocale #registrierte Locale, intern nur lesen (RegisterLocale ersetzt, aendert nicht)
+func defaultLocale() *Locale {
+	locales.Lock()
+	defer locales.Unlock()
+	return locales.m[locales.def]
+}
+
+// rebuildLocaleTokens #Kuerzel aller Locales, bei Mehrdeutigkeit gilt die Default-Locale, dann "de",
+// dann die Reihenfolge der Registrierung; Aufruf unter locales.Lock
+func rebuildLocaleTokens() {
+	names := []string{locales.def, "de"}
+	names = append(names, locales.order...)
+
+	locales.days = make(map[string]time.Weekday)
+	locales.months = make(map[string]time.Month)
+
+	for _, n := range names {
+		l, ok := locales.m[n]
+		if !ok {
+			continue
+		}
+
+		for d := 0; d < 7; d++ {
+			tokens := append([]string{l.DaysShort[d], l.Days[d]}, l.DayTokens[d]...)
+			for _, t := range tokens {
+				t = strings.ToLower(t)
+				if _, dup := locales.days[t]; !dup && len(t) > 0 {
+					locales.days[t] = time.Weekday(d)
+				}
+			}
+		}
+
+		for m := 0; m < 12; m++ {
+			for _, t := range []string{l.MonthsShort[m], l.Months[m]} {
+				t = strings.ToLower(t)
+				if _, dup := locales.months[t]; !dup && len(t) > 0 {
+					locales.months[t] = time.Month(m + 1)
+				}
+			}
+		}
+	}
+}
+
+// weekdayToken #Kuerzel oder Name eines Wochentags in einer registrierten Locale bzw. 0..6 (0 = Sonntag)
+func weekdayToken(s string) (time.Weekday, bool) {
+	if len(s) == 1 && s[0] >= '0' && s[0] <= '6' {
+		return time.Weekday(s[0] - '0'), true
+	}
+
+	locales.Lock()
+	defer locales.Unlock()
+
+	wd, ok := locales.days[strings.ToLower(s)]
+	return wd, ok
+}
+
+// monthToken #Kuerzel oder Name eines Monats in einer registrierten Locale
+func monthToken(s string) (time.Month, bool) {
+	locales.Lock()
+	defer locales.Unlock()
+
+	m, ok := locales.months[strings.ToLower(s)]
+	return m, ok
+}
+
+// Weekday #Name des Wochentags, "" ausserhalb von Sonntag..Samstag
+func (l *Locale) Weekday(d time.Weekday) string {
+	if d < time.Sunday || d > time.Saturday {
+		return ""
+	}
+	return l.Days[d]
+}
+
+// Month #Name des Monats, "" ausserhalb von Januar..Dezember
+func (l *Locale) Month(m time.Month) string {
+	if m < time.January || m > time.December {
+		return ""
+	}
+	return l.Months[m-1]
+}
+
+// layout-Elemente mit Namen, laengere zuerst
+var localeLayoutNames = []string{"Monday", "January", "Mon", "Jan"}
+
+// Format #wie time.Format, Monday, Mon, January und Jan werden durch die Namen der Locale ersetzt
+func (l *Locale) Format(t time.Time, layout string) string {
+	var sb strings.Builder
+
+	for len(layout) > 0 {
+		ix, tok := -1, ""
+		for _, n := range localeLayoutNames {
+			if i := strings.Index(layout, n); i >= 0 && (ix < 0 || i < ix || (i == ix && len(n) > len(tok))) {
+				ix, tok = i, n
+			}
+		}
+
+		if ix < 0 {
+			sb.WriteString(t.Format(layout))
+			break
+		}
+
+		if ix > 0 {
+			sb.WriteString(t.Format(layout[:ix]))
+		}
+
+		switch tok {
+		case "Monday":
+			sb.WriteString(l.Days[t.Weekday()])
+		case "Mon":
+			sb.WriteString(l.DaysShort[t.Weekday()])
+		case "January":
+			sb.WriteString(l.Months[t.Month()-1])
+		case "Jan":
+			sb.WriteString(l.MonthsShort[t.Month()-1])
+		}
+
+		layout = layout[ix+len(tok):]
+	}
+
+	return sb.String()
+}
+
+// FormatDate #Format mit der Default-Locale, z.B. FormatDate(t, "Monday, 2. January 2006")
+func FormatDate(t time.Time, layout string) string {
+	return defaultLocale().Format(t, layout)
+}
+
+// FormatDateLocale #Format mit einer registrierten Locale, unbekannt = Default-Locale
+func FormatDateLocale(t time.Time, layout string, locale string) string {
+	locales.Lock()
+	l := locales.m[locale]
+	locales.Unlock()
+
+	if l == nil {
+		l = defaultLocale()
+	}
+
+	return l.Format(t, layout)
+}
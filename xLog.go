@@ -0,0 +1,305 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xLog.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogLevel #
+type LogLevel int
+
+const (
+	// LevelDebug #
+	LevelDebug LogLevel = iota
+	// LevelInfo #Log, LogF
+	LevelInfo
+	// LevelWarn #
+	LevelWarn
+	// LevelError #
+	LevelError
+	// LevelFatal #Fatal, FatalF
+	LevelFatal
+)
+
+var levelNames = []string{"DEBUG", "INFO", "WARN", "ERROR", "FATAL"}
+
+func (l LogLevel) String() string {
+	if l < LevelDebug || l > LevelFatal {
+		return fmt.Sprintf("LEVEL(%d)", int(l))
+	}
+	return levelNames[l]
+}
+
+// ParseLogLevel #
+func ParseLogLevel(s string) (LogLevel, error) {
+	for i, n := range levelNames {
+		if strings.EqualFold(s, n) {
+			return LogLevel(i), nil
+		}
+	}
+
+	return LevelInfo, fmt.Errorf("unknown log level: %s", s)
+}
+
+// Logger #Log-Instanz mit eigenem Prefix, Verzeichnis und Level
+type Logger struct {
+	mu       sync.Mutex
+	name     string
+	dir      string
+	pfx      string
+	level    LogLevel
+	sinks    []logSinkEntry
+	tf       LogTimeFormat
+	fileName string
+	lim      logLimiter
+}
+
+var loggers = struct {
+	sync.Mutex
+	m map[string]*Logger
+}{m: make(map[string]*Logger)}
+
+var defaultLog = newLogger("", "", "", LevelInfo, DefaultLogTimeFormat)
+
+// newLogger #mit den Standard-Sinks stderr und file
+func newLogger(name, pfx, dir string, lev LogLevel, tf LogTimeFormat) *Logger {
+	l := &Logger{name: name, dir: dir, pfx: pfx, level: lev, tf: tf}
+	l.sinks = []logSinkEntry{
+		{name: SinkStderr, sink: NewWriterSink(os.Stderr), level: LevelDebug},
+		{name: SinkFile, sink: fileSink{l: l}, level: LevelDebug},
+	}
+
+	return l
+}
+
+// DefaultLogger #Instanz fuer Log, LogF, Fatal und SetLog
+func DefaultLogger() *Logger {
+	return defaultLog
+}
+
+// NewLogger #registriert eine benannte Log-Instanz, logDir leer = <CurrentDir>/log
+func NewLogger(name string, logPfx string, logDir string) *Logger {
+	if len(logDir) == 0 {
+		logDir = PathJoin(Global.CurrentDir, "log")
+	}
+
+	l := newLogger(name, logPfx, logDir, defaultLog.Level(), defaultLog.TimeFormat())
+
+	loggers.Lock()
+	loggers.m[name] = l
+	loggers.Unlock()
+
+	return l
+}
+
+// GetLogger #liefert die benannte Instanz, "" oder unbekannt = DefaultLogger
+func GetLogger(name string) *Logger {
+	loggers.Lock()
+	l, ok := loggers.m[name]
+	loggers.Unlock()
+
+	if !ok {
+		return defaultLog
+	}
+	return l
+}
+
+// RemoveLogger #
+func RemoveLogger(name string) {
+	loggers.Lock()
+	delete(loggers.m, name)
+	loggers.Unlock()
+}
+
+// Name #
+func (l *Logger) Name() string { return l.name }
+
+// SetLog #
+func (l *Logger) SetLog(logPfx string, logDir string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(logDir) > 0 {
+		l.dir = logDir
+	}
+
+	l.pfx = logPfx
+}
+
+// Dir #
+func (l *Logger) Dir() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.dir
+}
+
+// Prefix #
+func (l *Logger) Prefix() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.pfx
+}
+
+// FileName #zuletzt beschriebene Log-Datei
+func (l *Logger) FileName() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.fileName
+}
+
+// SetLevel #Meldungen unterhalb von lev werden verworfen
+func (l *Logger) SetLevel(lev LogLevel) {
+	l.mu.Lock()
+	l.level = lev
+	l.mu.Unlock()
+}
+
+// Level #
+func (l *Logger) Level() LogLevel {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.level
+}
+
+// SetStderr #Sink stderr ein- bzw. ausschalten
+func (l *Logger) SetStderr(on bool) {
+	if on {
+		l.AddSink(SinkStderr, NewWriterSink(os.Stderr), LevelDebug)
+		return
+	}
+
+	l.RemoveSink(SinkStderr)
+}
+
+// SetTimeFormat #Layout, Zeitzone und Genauigkeit der Zeitstempel, Zeitzone des Tageswechsels
+func (l *Logger) SetTimeFormat(f LogTimeFormat) {
+	l.mu.Lock()
+	l.tf = f
+	l.mu.Unlock()
+}
+
+// TimeFormat #
+func (l *Logger) TimeFormat() LogTimeFormat {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.tf
+}
+
+// STime #Zeitstempel im Format der Instanz
+func (l *Logger) STime(t time.Time) string {
+	return STimeOpt(t, l.TimeFormat())
+}
+
+// Enabled #
+func (l *Logger) Enabled(lev LogLevel) bool {
+	if l == defaultLog {
+		// -debug setzt das Level des DefaultLogger
+		DefaultParams()
+	}
+	return lev >= l.Level()
+}
+
+// Log #
+func (l *Logger) Log(v ...interface{}) {
+	l.logx(LevelInfo, "", fmt.Sprint(v...))
+}
+
+// LogF #
+func (l *Logger) LogF(format string, v ...interface{}) string {
+	return l.logx(LevelInfo, format, fmt.Sprintf(format, v...))
+}
+
+// DebugF #
+func (l *Logger) DebugF(format string, v ...interface{}) string {
+	return l.logx(LevelDebug, format, fmt.Sprintf(format, v...))
+}
+
+// WarnF #
+func (l *Logger) WarnF(format string, v ...interface{}) string {
+	return l.logx(LevelWarn, format, fmt.Sprintf(format, v...))
+}
+
+// ErrorF #
+func (l *Logger) ErrorF(format string, v ...interface{}) string {
+	return l.logx(LevelError, format, fmt.Sprintf(format, v...))
+}
+
+// logx #Meldung an alle Sinks, key fuer SetRateLimit (leer = Text)
+func (l *Logger) logx(lev LogLevel, key string, s string) (ss string) {
+	if !l.Enabled(lev) {
+		return
+	}
+
+	r := l.record(lev, s)
+	if lev >= LevelFatal {
+		l.Flush()
+	} else if len(r.Msg) > 0 {
+		ok, sum := l.lim.allow(lev, key, r.Msg, r.Time)
+		l.emitSummary(sum)
+		if !ok {
+			l.armFlush()
+			return
+		}
+	}
+
+	if len(r.Msg) > 0 {
+		ss = r.STime + r.Msg
+	}
+
+	l.emit(r, "")
+	return
+}
+
+// record #fuehrende CR/LF gehen nur an stderr, '#' am Ende unterdrueckt dort den Zeilenumbruch
+func (l *Logger) record(lev LogLevel, s string) *LogRecord {
+	now := Now()
+	r := &LogRecord{Time: now, Level: lev, Logger: l.name, STime: l.STime(now)}
+
+	buf := []rune(s)
+
+	i := 0
+	for i < len(buf) && (buf[i] == '\r' || buf[i] == '\n') {
+		i++
+	}
+	r.Lead = string(buf[:i])
+	buf = buf[i:]
+
+	if len(buf) > 0 && buf[len(buf)-1] == '#' {
+		buf = buf[:len(buf)-1]
+		r.NoLF = true
+	}
+	r.Msg = Redact(string(buf))
+
+	return r
+}
+
+// write #Zeile an <dir>/yyyy/mm/<pfx>yyyymmdd.log anhaengen
+func (l *Logger) write(t time.Time, stime string, s string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	mDir, fileName := logFilePath(l.dir, l.pfx, t, l.tf)
+
+	CreateDirIfNotExist(mDir)
+
+	l.fileName = fileName
+
+	txt := "\n"
+	if len(s) > 0 {
+		txt = txt + stime + " " + s
+	}
+	AppendFile(l.fileName, txt)
+}
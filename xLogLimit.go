@@ -0,0 +1,251 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xLogLimit.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimitAll #Schluessel fuer das Limit aller Meldungen ohne eigenes Limit
+const RateLimitAll = "*"
+
+// logLimiter #Unterdrueckung wiederholter Meldungen und Rate-Limits je Schluessel
+type logLimiter struct {
+	mu     sync.Mutex
+	window time.Duration
+	last   struct {
+		msg   string
+		lev   LogLevel
+		start time.Time
+		count int
+	}
+	limits map[string]rateLimit
+	state  map[string]*rateState
+	timer  Timer
+}
+
+type rateLimit struct {
+	n   int
+	per time.Duration
+}
+
+type rateState struct {
+	lev     LogLevel
+	start   time.Time
+	count   int
+	dropped int
+}
+
+// SetDedup #gleiche Meldungen innerhalb window werden zu einer Zeile
+// "last message repeated N times" zusammengefasst, 0 = aus
+func (l *Logger) SetDedup(window time.Duration) {
+	l.lim.mu.Lock()
+	l.lim.window = window
+	l.lim.mu.Unlock()
+}
+
+// SetRateLimit #hoechstens n Meldungen je key innerhalb per; key ist das Format
+// bei LogF/DebugF/.. bzw. der Text bei Log, RateLimitAll gilt fuer alle anderen; n <= 0 entfernt das Limit
+func (l *Logger) SetRateLimit(key string, n int, per time.Duration) {
+	l.lim.mu.Lock()
+	defer l.lim.mu.Unlock()
+
+	if l.lim.limits == nil {
+		l.lim.limits = make(map[string]rateLimit)
+		l.lim.state = make(map[string]*rateState)
+	}
+
+	if n <= 0 || per <= 0 {
+		delete(l.lim.limits, key)
+		return
+	}
+
+	l.lim.limits[key] = rateLimit{n: n, per: per}
+}
+
+// Flush #ausstehende Zusammenfassungen schreiben
+func (l *Logger) Flush() {
+	l.lim.mu.Lock()
+	msgs := l.lim.flush(Now(), true)
+	l.lim.mu.Unlock()
+
+	l.emitSummary(msgs)
+}
+
+// armFlush #Zusammenfassungen erscheinen auch ohne weitere Meldung, wenn ihr Fenster ablaeuft
+func (l *Logger) armFlush() {
+	l.lim.mu.Lock()
+	defer l.lim.mu.Unlock()
+
+	if l.lim.timer != nil {
+		return
+	}
+
+	// Timer der eingestellten Clock, damit auch FakeClock.Advance die Zusammenfassung ausloest
+	if due, ok := l.lim.nextDue(); ok {
+		t := NewTimer(due.Sub(Now()))
+		l.lim.timer = t
+		go func() {
+			<-t.C()
+			l.flushDue()
+		}()
+	}
+}
+
+func (l *Logger) flushDue() {
+	l.lim.mu.Lock()
+	l.lim.timer = nil
+	msgs := l.lim.flush(Now(), false)
+	l.lim.mu.Unlock()
+
+	l.emitSummary(msgs)
+	l.armFlush()
+}
+
+type limitMsg struct {
+	lev LogLevel
+	msg string
+}
+
+// allow #false = Meldung unterdruecken; sum enthaelt faellige Zusammenfassungen
+func (m *logLimiter) allow(lev LogLevel, key string, msg string, now time.Time) (ok bool, sum []limitMsg) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.window <= 0 && len(m.limits) == 0 {
+		return true, nil
+	}
+
+	sum = m.flush(now, false)
+
+	if len(key) == 0 {
+		key = msg
+	}
+
+	if lim, found := m.limitFor(key); found {
+		st := m.state[key]
+		if st == nil {
+			st = &rateState{start: now}
+			m.state[key] = st
+		}
+
+		if now.Sub(st.start) >= lim.per {
+			if st.dropped > 0 {
+				sum = append(sum, limitMsg{st.lev, fmt.Sprintf("%d messages suppressed by rate limit: %s", st.dropped, key)})
+			}
+			*st = rateState{start: now}
+		}
+
+		st.count++
+		if st.count > lim.n {
+			st.dropped++
+			st.lev = lev
+			return false, sum
+		}
+	}
+
+	if m.window > 0 {
+		if msg == m.last.msg && now.Sub(m.last.start) < m.window {
+			m.last.count++
+			return false, sum
+		}
+
+		if m.last.count > 0 {
+			sum = append(sum, m.repeated())
+		}
+
+		m.last.msg = msg
+		m.last.lev = lev
+		m.last.start = now
+		m.last.count = 0
+	}
+
+	return true, sum
+}
+
+func (m *logLimiter) limitFor(key string) (rateLimit, bool) {
+	if lim, ok := m.limits[key]; ok {
+		return lim, true
+	}
+
+	lim, ok := m.limits[RateLimitAll]
+	return lim, ok
+}
+
+func (m *logLimiter) repeated() limitMsg {
+	return limitMsg{m.last.lev, fmt.Sprintf("last message repeated %d times", m.last.count)}
+}
+
+// flush #abgelaufene (bzw. bei all alle) Zusammenfassungen, mu muss gesperrt sein
+func (m *logLimiter) flush(now time.Time, all bool) (sum []limitMsg) {
+	if m.last.count > 0 && (all || now.Sub(m.last.start) >= m.window) {
+		sum = append(sum, m.repeated())
+		m.last.count = 0
+		m.last.msg = ""
+	}
+
+	// abgelaufene Zustaende entfernen, sonst waechst state mit jedem Text (RateLimitAll)
+	for key, st := range m.state {
+		lim, found := m.limitFor(key)
+		if all || !found || now.Sub(st.start) >= lim.per {
+			if st.dropped > 0 {
+				sum = append(sum, limitMsg{st.lev, fmt.Sprintf("%d messages suppressed by rate limit: %s", st.dropped, key)})
+			}
+			delete(m.state, key)
+		}
+	}
+
+	return sum
+}
+
+// nextDue #naechste faellige Zusammenfassung, mu muss gesperrt sein
+func (m *logLimiter) nextDue() (due time.Time, ok bool) {
+	if m.last.count > 0 {
+		due, ok = m.last.start.Add(m.window), true
+	}
+
+	for key, st := range m.state {
+		if st.dropped == 0 {
+			continue
+		}
+
+		lim, _ := m.limitFor(key)
+		if t := st.start.Add(lim.per); !ok || t.Before(due) {
+			due, ok = t, true
+		}
+	}
+
+	return
+}
+
+func (l *Logger) emitSummary(msgs []limitMsg) {
+	for _, m := range msgs {
+		l.emit(l.record(m.lev, m.msg), "")
+	}
+}
+
+// flushLoggers #vor dem Beenden
+func flushLoggers() {
+	defaultLog.Flush()
+
+	loggers.Lock()
+	list := make([]*Logger, 0, len(loggers.m))
+	for _, l := range loggers.m {
+		list = append(list, l)
+	}
+	loggers.Unlock()
+
+	for _, l := range list {
+		l.Flush()
+	}
+}
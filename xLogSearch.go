@@ -0,0 +1,280 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xLogSearch.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrStopSearch #kann vom Callback geliefert werden, um die Suche ohne Fehler zu beenden
+var ErrStopSearch = errors.New("stop search")
+
+// LogQuery #Suchkriterien fuer SearchLog
+type LogQuery struct {
+	Dir        string    // log-Verzeichnis, leer = Verzeichnis des DefaultLogger
+	Prefix     string    // Prefix der Log-Dateien (<pfx>YYYYMMDD.log)
+	From       time.Time // inklusive, Zero = ohne Grenze nach unten
+	To         time.Time // inklusive, Zero = bis jetzt
+	Pattern    string    // regulaerer Ausdruck, leer = alle Zeilen
+	IgnoreCase bool
+	Format     *LogTimeFormat // Zeitstempel der Dateien, nil = Format des DefaultLogger
+}
+
+// LogLine #gefundener Eintrag
+type LogLine struct {
+	Time time.Time
+	File string
+	Line string // Originalzeile inkl. Folgezeilen ohne Zeitstempel
+}
+
+// SearchLog #durchsucht den log/yyyy/mm Baum und liefert die Treffer zeitlich sortiert an fn
+func SearchLog(q LogQuery, fn func(l LogLine) error) error {
+	dir := q.Dir
+	if len(dir) == 0 {
+		dir = defaultLog.Dir()
+	}
+
+	var re *regexp.Regexp
+	if len(q.Pattern) > 0 {
+		expr := q.Pattern
+		if q.IgnoreCase {
+			expr = "(?i)" + expr
+		}
+
+		var err error
+		if re, err = regexp.Compile(expr); err != nil {
+			return err
+		}
+	}
+
+	tf := defaultLog.TimeFormat()
+	if q.Format != nil {
+		tf = *q.Format
+	}
+
+	// Verzeichnisse und Dateinamen folgen der Rollover-Zeitzone
+	loc := tf.rollover()
+
+	to := q.To
+	if to.IsZero() {
+		to = Now()
+	}
+
+	from := q.From
+	if from.IsZero() {
+		from = firstLogMonth(dir, loc)
+		if from.IsZero() {
+			return nil
+		}
+	}
+
+	if to.Before(from) {
+		return errors.New("SearchLog: To is before From")
+	}
+
+	fDay := logDay(from.In(loc))
+	tDay := logDay(to.In(loc))
+
+	f := from.In(loc)
+	for m := time.Date(f.Year(), f.Month(), 1, 0, 0, 0, 0, loc); !m.After(to); m = m.AddDate(0, 1, 0) {
+		mDir := PathJoin(dir, m.Format("2006"), m.Format("01"))
+		if !DirExists(mDir) {
+			continue
+		}
+
+		days, err := logFilesByDay(mDir, q.Prefix)
+		if err != nil {
+			return err
+		}
+
+		for _, day := range days {
+			if day.day < fDay || day.day > tDay {
+				continue
+			}
+
+			var lines []LogLine
+			for _, f := range day.files {
+				if lines, err = readLogFile(f, tf, lines); err != nil {
+					return err
+				}
+			}
+
+			sort.SliceStable(lines, func(i, j int) bool { return lines[i].Time.Before(lines[j].Time) })
+
+			for _, l := range lines {
+				if l.Time.Before(from) || l.Time.After(to) {
+					continue
+				}
+
+				if re != nil && !re.MatchString(l.Line) {
+					continue
+				}
+
+				if err := fn(l); err != nil {
+					if err == ErrStopSearch {
+						return nil
+					}
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+type logDayFiles struct {
+	day   string
+	files []string
+}
+
+func logDay(t time.Time) string {
+	return t.Format("20060102")
+}
+
+// logFilesByDay #liefert <pfx>YYYYMMDD.log[.gz] eines Monatsverzeichnisses nach Tag sortiert
+func logFilesByDay(mDir string, pfx string) ([]logDayFiles, error) {
+	d, err := os.Open(mDir)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	names, err := d.Readdirnames(-1)
+	if err != nil {
+		return nil, err
+	}
+
+	byDay := make(map[string][]string)
+	for _, n := range names {
+		base := strings.TrimSuffix(n, ".gz")
+		if !strings.HasSuffix(base, ".log") {
+			continue
+		}
+
+		base = strings.TrimSuffix(base, ".log")
+		if !strings.HasPrefix(base, pfx) {
+			continue
+		}
+
+		day := base[len(pfx):]
+		if !isDigits(day, 8) {
+			continue
+		}
+
+		byDay[day] = append(byDay[day], PathJoin(mDir, n))
+	}
+
+	days := make([]logDayFiles, 0, len(byDay))
+	for k, v := range byDay {
+		sort.Strings(v)
+		days = append(days, logDayFiles{day: k, files: v})
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].day < days[j].day })
+
+	return days, nil
+}
+
+// firstLogMonth #aeltester Monat im log-Baum
+func firstLogMonth(dir string, loc *time.Location) time.Time {
+	years, _ := LoadDirs(dir, "[0-9][0-9][0-9][0-9]")
+	for _, y := range years {
+		months, _ := LoadDirs(PathJoin(dir, y), "[0-9][0-9]")
+		if len(months) > 0 {
+			return time.Date(Esubstr2int(y, 0, 4), time.Month(Esubstr2int(months[0], 0, 2)), 1, 0, 0, 0, 0, loc)
+		}
+	}
+
+	return time.Time{}
+}
+
+func readLogFile(fileName string, tf LogTimeFormat, lines []LogLine) ([]LogLine, error) {
+	r, err := openLogFile(fileName)
+	if err != nil {
+		return lines, err
+	}
+	defer r.Close()
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	for sc.Scan() {
+		s := string(dropCR(sc.Bytes()))
+		if len(s) == 0 {
+			continue
+		}
+
+		t, ok := tf.parseLogTime(s)
+		if !ok {
+			// Folgezeile ohne Zeitstempel gehoert zum vorherigen Eintrag
+			if n := len(lines); n > 0 && lines[n-1].File == fileName {
+				lines[n-1].Line += "\n" + s
+			}
+			continue
+		}
+
+		lines = append(lines, LogLine{Time: t, File: fileName, Line: s})
+	}
+
+	return lines, sc.Err()
+}
+
+type gzipFile struct {
+	*gzip.Reader
+	f *os.File
+}
+
+func (g gzipFile) Close() error {
+	g.Reader.Close()
+	return g.f.Close()
+}
+
+// openLogFile #.gz wird transparent entpackt
+func openLogFile(fileName string) (io.ReadCloser, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasSuffix(fileName, ".gz") {
+		return f, nil
+	}
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return gzipFile{Reader: gr, f: f}, nil
+}
+
+func isDigits(s string, le int) bool {
+	if len(s) != le {
+		return false
+	}
+
+	for i := 0; i < le; i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
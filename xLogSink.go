@@ -0,0 +1,338 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xLogSink.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// LogRecord #eine Log-Meldung wie sie an die Sinks geht
+type LogRecord struct {
+	Time   time.Time `json:"time"`
+	Level  LogLevel  `json:"level"`
+	Logger string    `json:"logger"`
+	STime  string    `json:"-"` // formatierter Zeitstempel inkl. Leerzeichen
+	Msg    string    `json:"msg"`
+	Lead   string    `json:"-"` // fuehrende CR/LF der Meldung
+	NoLF   bool      `json:"-"` // Meldung endete mit '#'
+}
+
+// LogSink #Ziel fuer Log-Meldungen
+type LogSink interface {
+	WriteLog(r *LogRecord) error
+}
+
+// Standard-Sinks jeder Logger-Instanz
+const (
+	SinkStderr = "stderr"
+	SinkFile   = "file"
+)
+
+type logSinkEntry struct {
+	name  string
+	sink  LogSink
+	level LogLevel
+}
+
+// AddSink #fuegt einen Sink hinzu bzw. ersetzt den gleichnamigen
+//
+// l.sinks wird nie veraendert, sondern ersetzt: emit arbeitet ohne Lock auf einer Kopie des Slice.
+func (l *Logger) AddSink(name string, s LogSink, minLevel LogLevel) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e := logSinkEntry{name: name, sink: s, level: minLevel}
+	sinks := make([]logSinkEntry, 0, len(l.sinks)+1)
+	replaced := false
+	for _, x := range l.sinks {
+		if x.name == name {
+			x = e
+			replaced = true
+		}
+		sinks = append(sinks, x)
+	}
+
+	if !replaced {
+		sinks = append(sinks, e)
+	}
+	l.sinks = sinks
+}
+
+// RemoveSink #liefert den entfernten Sink oder nil
+func (l *Logger) RemoveSink(name string) LogSink {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var removed LogSink
+	sinks := make([]logSinkEntry, 0, len(l.sinks))
+	for _, e := range l.sinks {
+		if e.name == name {
+			removed = e.sink
+			continue
+		}
+		sinks = append(sinks, e)
+	}
+
+	if removed != nil {
+		l.sinks = sinks
+	}
+	return removed
+}
+
+// Sink #
+func (l *Logger) Sink(name string) LogSink {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, e := range l.sinks {
+		if e.name == name {
+			return e.sink
+		}
+	}
+
+	return nil
+}
+
+// SinkNames #
+func (l *Logger) SinkNames() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	names := make([]string, len(l.sinks))
+	for i, e := range l.sinks {
+		names[i] = e.name
+	}
+
+	return names
+}
+
+// emit #Meldung an alle Sinks ausser skip
+func (l *Logger) emit(r *LogRecord, skip string) {
+	l.mu.Lock()
+	sinks := l.sinks
+	l.mu.Unlock()
+
+	for _, e := range sinks {
+		if e.name == skip || r.Level < e.level {
+			continue
+		}
+
+		if err := e.sink.WriteLog(r); err != nil && e.name != SinkStderr {
+			PrintStdErr("\nlog sink %s: %v\n", e.name, err)
+		}
+	}
+}
+
+// WriterSink #schreibt wie bisher auf stderr
+type WriterSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterSink #
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+// WriteLog #
+func (s *WriterSink) WriteLog(r *LogRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	txt := r.Lead
+	if len(r.Msg) > 0 {
+		txt += r.STime + r.Msg
+		if !r.NoLF {
+			txt += "\n"
+		}
+	}
+
+	_, err := io.WriteString(s.w, txt)
+	return err
+}
+
+// fileSink #<dir>/yyyy/mm/<pfx>yyyymmdd.log des Loggers
+type fileSink struct {
+	l *Logger
+}
+
+func (s fileSink) WriteLog(r *LogRecord) error {
+	s.l.write(r.Time, r.STime, r.Msg)
+	return nil
+}
+
+// RingSink #haelt die letzten N Meldungen im Speicher
+type RingSink struct {
+	mu   sync.Mutex
+	buf  []LogRecord
+	next int
+	full bool
+}
+
+// NewRingSink #
+func NewRingSink(n int) *RingSink {
+	if n < 1 {
+		n = 1
+	}
+	return &RingSink{buf: make([]LogRecord, n)}
+}
+
+// WriteLog #
+func (s *RingSink) WriteLog(r *LogRecord) error {
+	if len(r.Msg) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.buf[s.next] = *r
+	s.next++
+	if s.next == len(s.buf) {
+		s.next = 0
+		s.full = true
+	}
+
+	return nil
+}
+
+// Entries #aelteste zuerst
+func (s *RingSink) Entries() []LogRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.full {
+		return append([]LogRecord(nil), s.buf[:s.next]...)
+	}
+
+	out := make([]LogRecord, 0, len(s.buf))
+	out = append(out, s.buf[s.next:]...)
+	return append(out, s.buf[:s.next]...)
+}
+
+// Clear #
+func (s *RingSink) Clear() {
+	s.mu.Lock()
+	s.next = 0
+	s.full = false
+	s.mu.Unlock()
+}
+
+// RemoteSink #sendet Meldungen als JSON per HTTP-POST an einen Collector
+type RemoteSink struct {
+	url    string
+	client *http.Client
+	queue  chan LogRecord
+	done   chan struct{}
+
+	mu      sync.Mutex
+	closed  bool
+	dropped int
+}
+
+// NewRemoteSink #bufSize Meldungen werden gepuffert, bei vollem Puffer verworfen
+func NewRemoteSink(url string, bufSize int) *RemoteSink {
+	if bufSize < 1 {
+		bufSize = 100
+	}
+
+	s := &RemoteSink{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+		queue:  make(chan LogRecord, bufSize),
+		done:   make(chan struct{}),
+	}
+
+	go s.run()
+	return s
+}
+
+// WriteLog #blockiert nicht
+func (s *RemoteSink) WriteLog(r *LogRecord) error {
+	if len(r.Msg) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return errors.New("remote sink closed")
+	}
+
+	select {
+	case s.queue <- *r:
+	default:
+		s.dropped++
+	}
+
+	return nil
+}
+
+// Dropped #Anzahl verworfener Meldungen
+func (s *RemoteSink) Dropped() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// Close #sendet den Rest des Puffers und beendet den Sender
+func (s *RemoteSink) Close() {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	s.mu.Unlock()
+
+	<-s.done
+}
+
+func (s *RemoteSink) run() {
+	defer close(s.done)
+
+	for r := range s.queue {
+		if err := s.post(&r); err != nil {
+			PrintStdErr("\nremote log %s: %v\n", s.url, err)
+		}
+	}
+}
+
+func (s *RemoteSink) post(r *LogRecord) error {
+	data, err := json.Marshal(struct {
+		Time   string `json:"time"`
+		Level  string `json:"level"`
+		Logger string `json:"logger"`
+		Msg    string `json:"msg"`
+	}{r.Time.Format(time.RFC3339Nano), r.Level.String(), r.Logger, r.Msg})
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("status %s", resp.Status)
+	}
+
+	return nil
+}
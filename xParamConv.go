@@ -0,0 +1,374 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xParamConv.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParamTimeLayouts #Layouts fuer ParamAsTime ohne eigene Angabe
+var ParamTimeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"02.01.2006 15:04:05",
+	"02.01.2006 15:04",
+	"02.01.2006",
+	time.RFC3339,
+	"20060102150405",
+	"20060102",
+}
+
+var sizeUnits = map[string]uint{
+	"": 0, "b": 0,
+	"k": 1, "kb": 1, "kib": 1,
+	"m": 2, "mb": 2, "mib": 2,
+	"g": 3, "gb": 3, "gib": 3,
+	"t": 4, "tb": 4, "tib": 4,
+	"p": 5, "pb": 5, "pib": 5,
+	"e": 6, "eb": 6, "eib": 6,
+}
+
+// ParseBool #1/0, true/false, ja/nein, yes/no, on/off
+func ParseBool(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1", "true", "t", "ja", "j", "yes", "y", "on":
+		return true, nil
+	case "0", "false", "f", "nein", "n", "no", "off":
+		return false, nil
+	}
+
+	return false, fmt.Errorf("invalid bool: %q", s)
+}
+
+// ParseSize #Gegenstueck zu ReadableBytes: "10MB", "1.5 GB", "512", Basis 1024
+func ParseSize(s string) (uint64, error) {
+	t := strings.TrimSpace(s)
+
+	i := 0
+	for i < len(t) && (t[i] >= '0' && t[i] <= '9' || t[i] == '.' || t[i] == ',') {
+		i++
+	}
+
+	if i == 0 {
+		return 0, fmt.Errorf("invalid size: %q", s)
+	}
+
+	n, err := strconv.ParseFloat(strings.Replace(t[:i], ",", ".", 1), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size: %q", s)
+	}
+
+	e, ok := sizeUnits[strings.ToLower(strings.TrimSpace(t[i:]))]
+	if !ok {
+		return 0, fmt.Errorf("invalid size unit: %q", s)
+	}
+
+	v := n * math.Pow(1024, float64(e))
+	if v >= math.MaxUint64 {
+		return 0, fmt.Errorf("size overflow: %q", s)
+	}
+
+	return uint64(v), nil
+}
+
+// ParseFloat #Dezimalpunkt oder -komma
+func ParseFloat(s string) (float64, error) {
+	return strconv.ParseFloat(strings.Replace(strings.TrimSpace(s), ",", ".", 1), 64)
+}
+
+// ParseTimeLayouts #erstes passendes Layout, ohne Angabe ParamTimeLayouts; Ortszeit
+func ParseTimeLayouts(s string, layouts ...string) (time.Time, error) {
+	if len(layouts) == 0 {
+		layouts = ParamTimeLayouts
+	}
+
+	s = strings.TrimSpace(s)
+	for _, l := range layouts {
+		if t, err := time.ParseInLocation(l, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time: %q", s)
+}
+
+// SplitList #Trennung mit Komma oder Semikolon, leere Eintraege entfallen
+func SplitList(s string) []string {
+	var a []string
+	for _, v := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' }) {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			a = append(a, v)
+		}
+	}
+
+	return a
+}
+
+// MatchEnum #liefert den Eintrag aus allowed (ohne Gross-/Kleinschreibung)
+func MatchEnum(s string, allowed []string) (string, error) {
+	for _, a := range allowed {
+		if strings.EqualFold(a, s) {
+			return a, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid value %q, allowed: %s", s, strings.Join(allowed, ", "))
+}
+
+// parseDurationValue #ParseDuration, eine reine Zahl sind Sekunden
+func parseDurationValue(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Duration(n) * time.Second, nil
+	}
+
+	return ParseDuration(s)
+}
+
+// value #Wert oder ok = false wenn nicht bzw. leer angegeben
+func (p *Params) value(sKey string) (string, bool) {
+	v, ok := p.Exist(sKey)
+	return v, ok && len(v) > 0
+}
+
+func paramErr(sKey string, err error) error {
+	return fmt.Errorf("parameter -%s: %v", strings.ToLower(sKey), err)
+}
+
+// AsIntErr #def wenn nicht angegeben, Fehler bei ungueltigem Wert
+func (p *Params) AsIntErr(sKey string, def int) (int, error) {
+	v, ok := p.value(sKey)
+	if !ok {
+		return def, nil
+	}
+
+	i, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil {
+		return def, paramErr(sKey, err)
+	}
+
+	return i, nil
+}
+
+// AsBoolErr #-v ohne Wert ist true
+func (p *Params) AsBoolErr(sKey string, def bool) (bool, error) {
+	v, ok := p.Exist(sKey)
+	if !ok {
+		return def, nil
+	}
+
+	if len(v) == 0 {
+		return true, nil
+	}
+
+	b, err := ParseBool(v)
+	if err != nil {
+		return def, paramErr(sKey, err)
+	}
+
+	return b, nil
+}
+
+// AsBool #
+func (p *Params) AsBool(sKey string, def bool) bool {
+	b, _ := p.AsBoolErr(sKey, def)
+	return b
+}
+
+// AsDurationErr #"90s", "1h30m", Zahl = Sekunden
+func (p *Params) AsDurationErr(sKey string, def time.Duration) (time.Duration, error) {
+	v, ok := p.value(sKey)
+	if !ok {
+		return def, nil
+	}
+
+	d, err := parseDurationValue(v)
+	if err != nil {
+		return def, paramErr(sKey, err)
+	}
+
+	return d, nil
+}
+
+// AsDuration #
+func (p *Params) AsDuration(sKey string, def time.Duration) time.Duration {
+	d, _ := p.AsDurationErr(sKey, def)
+	return d
+}
+
+// AsSizeErr #"10MB", "1.5 GB"
+func (p *Params) AsSizeErr(sKey string, def uint64) (uint64, error) {
+	v, ok := p.value(sKey)
+	if !ok {
+		return def, nil
+	}
+
+	n, err := ParseSize(v)
+	if err != nil {
+		return def, paramErr(sKey, err)
+	}
+
+	return n, nil
+}
+
+// AsSize #
+func (p *Params) AsSize(sKey string, def uint64) uint64 {
+	n, _ := p.AsSizeErr(sKey, def)
+	return n
+}
+
+// AsTimeErr #layouts leer = ParamTimeLayouts
+func (p *Params) AsTimeErr(sKey string, def time.Time, layouts ...string) (time.Time, error) {
+	v, ok := p.value(sKey)
+	if !ok {
+		return def, nil
+	}
+
+	t, err := ParseTimeLayouts(v, layouts...)
+	if err != nil {
+		return def, paramErr(sKey, err)
+	}
+
+	return t, nil
+}
+
+// AsTime #
+func (p *Params) AsTime(sKey string, def time.Time, layouts ...string) time.Time {
+	t, _ := p.AsTimeErr(sKey, def, layouts...)
+	return t
+}
+
+// AsList #"a,b;c"
+func (p *Params) AsList(sKey string, def []string) []string {
+	v, ok := p.value(sKey)
+	if !ok {
+		return def
+	}
+
+	return SplitList(v)
+}
+
+// AsFloatErr #
+func (p *Params) AsFloatErr(sKey string, def float64) (float64, error) {
+	v, ok := p.value(sKey)
+	if !ok {
+		return def, nil
+	}
+
+	f, err := ParseFloat(v)
+	if err != nil {
+		return def, paramErr(sKey, err)
+	}
+
+	return f, nil
+}
+
+// AsFloat #
+func (p *Params) AsFloat(sKey string, def float64) float64 {
+	f, _ := p.AsFloatErr(sKey, def)
+	return f
+}
+
+// AsEnumErr #Wert muss in allowed enthalten sein
+func (p *Params) AsEnumErr(sKey string, def string, allowed ...string) (string, error) {
+	v, ok := p.value(sKey)
+	if !ok {
+		return def, nil
+	}
+
+	e, err := MatchEnum(v, allowed)
+	if err != nil {
+		return def, paramErr(sKey, err)
+	}
+
+	return e, nil
+}
+
+// AsEnum #
+func (p *Params) AsEnum(sKey string, def string, allowed ...string) string {
+	e, _ := p.AsEnumErr(sKey, def, allowed...)
+	return e
+}
+
+// ParamAsIntErr #
+func ParamAsIntErr(sKey string, def int) (int, error) {
+	return DefaultParams().AsIntErr(sKey, def)
+}
+
+// ParamAsBool #1/true/ja/yes, -v ohne Wert = true
+func ParamAsBool(sKey string, def bool) bool {
+	return DefaultParams().AsBool(sKey, def)
+}
+
+// ParamAsBoolErr #
+func ParamAsBoolErr(sKey string, def bool) (bool, error) {
+	return DefaultParams().AsBoolErr(sKey, def)
+}
+
+// ParamAsDuration #
+func ParamAsDuration(sKey string, def time.Duration) time.Duration {
+	return DefaultParams().AsDuration(sKey, def)
+}
+
+// ParamAsDurationErr #
+func ParamAsDurationErr(sKey string, def time.Duration) (time.Duration, error) {
+	return DefaultParams().AsDurationErr(sKey, def)
+}
+
+// ParamAsSize #
+func ParamAsSize(sKey string, def uint64) uint64 {
+	return DefaultParams().AsSize(sKey, def)
+}
+
+// ParamAsSizeErr #
+func ParamAsSizeErr(sKey string, def uint64) (uint64, error) {
+	return DefaultParams().AsSizeErr(sKey, def)
+}
+
+// ParamAsTime #
+func ParamAsTime(sKey string, def time.Time, layouts ...string) time.Time {
+	return DefaultParams().AsTime(sKey, def, layouts...)
+}
+
+// ParamAsTimeErr #
+func ParamAsTimeErr(sKey string, def time.Time, layouts ...string) (time.Time, error) {
+	return DefaultParams().AsTimeErr(sKey, def, layouts...)
+}
+
+// ParamAsList #
+func ParamAsList(sKey string, def []string) []string {
+	return DefaultParams().AsList(sKey, def)
+}
+
+// ParamAsFloat #
+func ParamAsFloat(sKey string, def float64) float64 {
+	return DefaultParams().AsFloat(sKey, def)
+}
+
+// ParamAsFloatErr #
+func ParamAsFloatErr(sKey string, def float64) (float64, error) {
+	return DefaultParams().AsFloatErr(sKey, def)
+}
+
+// ParamAsEnum #
+func ParamAsEnum(sKey string, def string, allowed ...string) string {
+	return DefaultParams().AsEnum(sKey, def, allowed...)
+}
+
+// ParamAsEnumErr #
+func ParamAsEnumErr(sKey string, def string, allowed ...string) (string, error) {
+	return DefaultParams().AsEnumErr(sKey, def, allowed...)
+}
@@ -0,0 +1,453 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xParamDef.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParamType #
+type ParamType int
+
+const (
+	// TypeString #
+	TypeString ParamType = iota
+	// TypeInt #
+	TypeInt
+	// TypeBool #-v, -v=ja, -v=0
+	TypeBool
+	// TypeFloat #
+	TypeFloat
+	// TypeDuration #"90s", "1h30m", Zahl = Sekunden
+	TypeDuration
+	// TypeSize #"10MB"
+	TypeSize
+	// TypeTime #siehe ParamTimeLayouts
+	TypeTime
+	// TypeList #"a,b;c"
+	TypeList
+	// TypeEnum #einer der Werte aus ParamDef.Enum
+	TypeEnum
+	// TypeFile #Dateipfad, fuer die Shell-Completion
+	TypeFile
+)
+
+var paramTypeNames = []string{"string", "int", "bool", "float", "duration", "size", "time", "list", "enum", "file"}
+
+func (t ParamType) String() string {
+	if t < TypeString || int(t) >= len(paramTypeNames) {
+		return "type(" + strconv.Itoa(int(t)) + ")"
+	}
+	return paramTypeNames[t]
+}
+
+// ErrHelp #-h, -?, -help bzw. -completion wurde angegeben, die Ausgabe ist erfolgt
+var ErrHelp = errors.New("help requested")
+
+// ParamDef #Beschreibung eines Parameters
+type ParamDef struct {
+	Name     string
+	Aliases  []string
+	Type     ParamType
+	Default  string
+	Desc     string
+	Required bool
+	Enum     []string // erlaubte Werte bei TypeEnum
+}
+
+// ParamDefs #deklarierte Parameter eines Programms
+type ParamDefs struct {
+	Usage string // z.B. "gstock [options] <file>"
+
+	defs   []*ParamDef
+	byName map[string]*ParamDef
+	values map[string]string
+}
+
+// immer erlaubte Parameter
+var builtinParams = []string{"debug", "h", "?", "help", "completion"}
+
+// NewParamDefs #
+func NewParamDefs(usage string) *ParamDefs {
+	return &ParamDefs{Usage: usage, byName: make(map[string]*ParamDef), values: make(map[string]string)}
+}
+
+// Add #Name und Aliase muessen eindeutig sein, sonst panic;
+// deklarierte Namen wie quiet gehen -qVALUE vor, -quiet ist dann nicht -q mit Wert "uiet"
+func (d *ParamDefs) Add(def ParamDef) *ParamDefs {
+	if err := d.add(def); err != nil {
+		panic("ParamDefs: " + err.Error())
+	}
+	return d
+}
+
+// add #wie Add, liefert bei Kollision einen Fehler und aendert dann nichts
+func (d *ParamDefs) add(def ParamDef) error {
+	p := def
+	p.Name = strings.ToLower(p.Name)
+
+	names := append([]string{p.Name}, p.Aliases...)
+	for i, n := range names {
+		n = strings.ToLower(n)
+		if _, ok := d.byName[n]; ok || len(n) == 0 {
+			return errors.New("duplicate or empty parameter name: " + n)
+		}
+		names[i] = n
+	}
+
+	for _, n := range names {
+		d.byName[n] = &p
+	}
+
+	d.defs = append(d.defs, &p)
+	return nil
+}
+
+// Def #Definition zu Name oder Alias
+func (d *ParamDefs) Def(name string) *ParamDef {
+	return d.byName[strings.ToLower(name)]
+}
+
+// Defs #in Reihenfolge der Deklaration
+func (d *ParamDefs) Defs() []ParamDef {
+	a := make([]ParamDef, len(d.defs))
+	for i, p := range d.defs {
+		a[i] = *p
+	}
+	return a
+}
+
+// Parse #prueft die Kommandozeile (DefaultParams)
+func (d *ParamDefs) Parse() error {
+	return d.ParseParams(DefaultParams())
+}
+
+// ParseParams #bei -h/-?/-help bzw. -completion=<shell> wird die Hilfe
+// bzw. das Completion-Skript ausgegeben und ErrHelp geliefert
+func (d *ParamDefs) ParseParams(p *Params) error {
+	if ok, err := writeCompletion(p, &Command{Name: progName(), Params: d}); ok {
+		if err != nil {
+			return err
+		}
+		return ErrHelp
+	}
+
+	if p.Exists([]string{"h", "?", "help"}) {
+		fmt.Print(d.Help())
+		return ErrHelp
+	}
+
+	return d.checkParams(p)
+}
+
+// checkParams #wie Check, bei ModePosix erhalten deklarierte Wert-Parameter (-o datei)
+// den folgenden Positions-Parameter
+func (d *ParamDefs) checkParams(p *Params) error {
+	p = p.reparse(d.glueNames())
+
+	xargs := p.Map()
+	if len(p.next) > 0 {
+		xargs = make(map[string]string, len(p.xargs))
+		for k, v := range p.xargs {
+			xargs[k] = v
+		}
+
+		for k, v := range p.next {
+			if def := d.Def(k); def != nil && def.Type != TypeBool && len(xargs[k]) == 0 {
+				xargs[k] = v
+			}
+		}
+	}
+
+	return d.Check(xargs, p.Args())
+}
+
+// glueNames #deklarierte Namen und Aliase, die sonst als -qVALUE/-xVALUE gelesen wuerden
+func (d *ParamDefs) glueNames() map[string]bool {
+	names := make(map[string]bool)
+	for n := range d.byName {
+		if len(n) > 1 && (n[0] == 'q' || n[0] == 'x') {
+			names[n] = true
+		}
+	}
+	return names
+}
+
+// Check #unbekannte, fehlerhafte und fehlende Pflicht-Parameter werden gemeldet
+func (d *ParamDefs) Check(xargs map[string]string, positional []string) error {
+	var msgs []string
+	values := make(map[string]string)
+
+	keys := make([]string, 0, len(xargs))
+	for k := range xargs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v := xargs[k]
+		p, ok := d.byName[k]
+		if !ok {
+			if !isBuiltinParam(k) {
+				msgs = append(msgs, "unknown parameter -"+k)
+			}
+			continue
+		}
+
+		if _, dup := values[p.Name]; dup {
+			msgs = append(msgs, "parameter -"+p.Name+" given more than once")
+			continue
+		}
+
+		// "/v datei": bei bool ist der folgende Positions-Parameter kein Wert
+		if p.Type == TypeBool && len(v) > 0 && containsStr(positional, v) {
+			if _, err := ParseBool(v); err != nil {
+				v = ""
+			}
+		}
+
+		// -host ohne Wert: Umgebung bzw. Konfigurationsdatei
+		if p.Type != TypeBool && len(v) == 0 {
+			if cv, _, ok := configLookup(p.Name); ok {
+				v = cv
+			}
+		}
+
+		if err := checkParamValue(p, v); err != nil {
+			msgs = append(msgs, fmt.Sprintf("invalid value for -%s: %v", p.Name, err))
+			continue
+		}
+
+		if p.Type == TypeBool && len(v) == 0 {
+			v = "1"
+		}
+		values[p.Name] = v
+	}
+
+	for _, p := range d.defs {
+		if _, ok := values[p.Name]; ok {
+			continue
+		}
+
+		// Umgebung bzw. Konfigurationsdatei (siehe ParamValue)
+		if v, src, ok := configLookup(p.Name); ok {
+			if err := checkParamValue(p, v); err != nil {
+				msgs = append(msgs, fmt.Sprintf("invalid value for -%s (%s): %v", p.Name, src, err))
+			} else {
+				values[p.Name] = v
+			}
+			continue
+		}
+
+		if p.Required {
+			msgs = append(msgs, "missing required parameter -"+p.Name)
+		}
+	}
+
+	d.values = values
+
+	if len(msgs) > 0 {
+		return errors.New(strings.Join(msgs, "\n"))
+	}
+
+	return nil
+}
+
+func isBuiltinParam(k string) bool {
+	return containsStr(builtinParams, k)
+}
+
+func containsStr(a []string, s string) bool {
+	for _, x := range a {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+func checkParamValue(p *ParamDef, v string) error {
+	if len(v) == 0 {
+		if p.Type == TypeBool || p.Type == TypeString || p.Type == TypeFile {
+			return nil
+		}
+		return errors.New("value missing")
+	}
+
+	var err error
+	switch p.Type {
+	case TypeInt:
+		_, err = strconv.Atoi(v)
+	case TypeBool:
+		_, err = ParseBool(v)
+	case TypeFloat:
+		_, err = ParseFloat(v)
+	case TypeDuration:
+		_, err = parseDurationValue(v)
+	case TypeSize:
+		_, err = ParseSize(v)
+	case TypeTime:
+		_, err = ParseTimeLayouts(v)
+	case TypeEnum:
+		_, err = MatchEnum(v, p.Enum)
+	}
+
+	return err
+}
+
+// Exist #Parameter wurde angegeben
+func (d *ParamDefs) Exist(name string) bool {
+	p := d.Def(name)
+	if p == nil {
+		return false
+	}
+
+	_, ok := d.values[p.Name]
+	return ok
+}
+
+// String #Wert oder Default
+func (d *ParamDefs) String(name string) string {
+	p := d.Def(name)
+	if p == nil {
+		return ""
+	}
+
+	if v, ok := d.values[p.Name]; ok && len(v) > 0 {
+		return v
+	}
+
+	if v, _, ok := configLookup(p.Name); ok && len(v) > 0 {
+		return v
+	}
+
+	return p.Default
+}
+
+// Int #
+func (d *ParamDefs) Int(name string) int {
+	i, _ := strconv.Atoi(d.String(name))
+	return i
+}
+
+// Bool #
+func (d *ParamDefs) Bool(name string) bool {
+	b, _ := ParseBool(d.String(name))
+	return b
+}
+
+// Float #Komma oder Punkt
+func (d *ParamDefs) Float(name string) float64 {
+	f, _ := ParseFloat(d.String(name))
+	return f
+}
+
+// Duration #
+func (d *ParamDefs) Duration(name string) time.Duration {
+	t, _ := parseDurationValue(d.String(name))
+	return t
+}
+
+// Size #
+func (d *ParamDefs) Size(name string) uint64 {
+	n, _ := ParseSize(d.String(name))
+	return n
+}
+
+// Time #
+func (d *ParamDefs) Time(name string) time.Time {
+	t, _ := ParseTimeLayouts(d.String(name))
+	return t
+}
+
+// List #
+func (d *ParamDefs) List(name string) []string {
+	return SplitList(d.String(name))
+}
+
+// Enum #Schreibweise aus ParamDef.Enum
+func (d *ParamDefs) Enum(name string) string {
+	p := d.Def(name)
+	if p == nil {
+		return ""
+	}
+
+	e, _ := MatchEnum(d.String(name), p.Enum)
+	return e
+}
+
+// Help #Usage-Text aus den Definitionen
+func (d *ParamDefs) Help() string {
+	var b Buffer
+
+	usage := d.Usage
+	if len(usage) == 0 {
+		usage = progName() + " [options]"
+	}
+	b.WriteLine("usage: " + usage)
+
+	if len(d.defs) == 0 {
+		return b.String()
+	}
+	b.WriteLine("")
+
+	cols := make([]string, len(d.defs))
+	w := 0
+	for i, p := range d.defs {
+		s := "-" + p.Name
+		for _, a := range p.Aliases {
+			s += ", -" + strings.ToLower(a)
+		}
+		switch p.Type {
+		case TypeBool:
+		case TypeEnum:
+			s += " <" + strings.Join(p.Enum, "|") + ">"
+		default:
+			s += " <" + p.Type.String() + ">"
+		}
+		cols[i] = s
+
+		if len(s) > w {
+			w = len(s)
+		}
+	}
+
+	for i, p := range d.defs {
+		desc := p.Desc
+		if p.Required {
+			desc += " (required)"
+		} else if len(p.Default) > 0 {
+			desc += " (default: " + p.Default + ")"
+		}
+		b.WriteLine(fmt.Sprintf("  %-*s  %s", w, cols[i], strings.TrimSpace(desc)))
+	}
+
+	return b.String()
+}
+
+func progName() string {
+	if len(os.Args) == 0 {
+		return "prog"
+	}
+
+	name := os.Args[0]
+	if ix := strings.LastIndexAny(name, `/\`); ix >= 0 {
+		name = name[ix+1:]
+	}
+
+	return strings.TrimSuffix(name, ".exe")
+}
@@ -0,0 +1,562 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xParams.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Params #geparste Argumente: -key=val, /key val, -qVALUE, -xVALUE, @datei, --, Positions-Parameter
+type Params struct {
+	xargs map[string]string
+	multi map[string][]string
+	pos   []string
+	next  map[string]string // ModePosix: -o datei, Wert nur fuer deklarierte Parameter (ParamDefs)
+
+	args  []string        // nach @datei, fuer erneutes Parsen mit deklarierten Namen
+	mode  ParseMode       // bereits aufgeloest (resolve)
+	names map[string]bool // deklarierte Namen mit q/x am Anfang, gehen -qVALUE/-xVALUE vor
+
+	// Index in pos: Positions-Parameter ist Wert der Option (-pwd geheim) bzw. bei ModePosix
+	// moeglicher Wert einer kurzen Option (-o datei), fuer die Suche nach dem Kommando
+	vals  map[int]string
+	short map[int]string
+}
+
+// maximale Schachtelung von @datei
+const maxResponseDepth = 8
+
+// ParseMode #Options-Syntax fuer Parse
+type ParseMode int
+
+const (
+	// ModeCompat #bisheriges Verhalten: -key=val und /key val, -qVALUE, -xVALUE
+	ModeCompat ParseMode = iota
+	// ModeWindows #nur /key, /key=val, /key:val, /key val; "-.." ist Positions-Parameter
+	ModeWindows
+	// ModePosix #-abc (gebuendelt), --key, --key=val, -key=val; "/.." ist Positions-Parameter
+	ModePosix
+	// ModeAuto #ModeWindows unter Windows, sonst ModePosix
+	ModeAuto
+)
+
+var parseMode = struct {
+	sync.Mutex
+	mode   ParseMode
+	frozen bool // DefaultParams hat os.Args bereits geparst
+}{mode: ModeCompat}
+
+// ErrParseModeFrozen #SetParseMode nach dem ersten Param-Zugriff
+var ErrParseModeFrozen = errors.New("SetParseMode: command line already parsed")
+
+// SetParseMode #gilt fuer Parse und DefaultParams, nach dem ersten Param-Zugriff
+// (auch Logger.Enabled) bleibt der Modus unveraendert und ErrParseModeFrozen wird geliefert
+func SetParseMode(m ParseMode) error {
+	parseMode.Lock()
+	defer parseMode.Unlock()
+
+	if parseMode.frozen {
+		return ErrParseModeFrozen
+	}
+
+	parseMode.mode = m
+	return nil
+}
+
+// GetParseMode #
+func GetParseMode() ParseMode {
+	parseMode.Lock()
+	defer parseMode.Unlock()
+	return parseMode.mode
+}
+
+// freezeParseMode #DefaultParams: Modus festschreiben
+func freezeParseMode() ParseMode {
+	parseMode.Lock()
+	defer parseMode.Unlock()
+	parseMode.frozen = true
+	return parseMode.mode
+}
+
+func (m ParseMode) resolve() ParseMode {
+	if m != ModeAuto {
+		return m
+	}
+
+	if runtime.GOOS == "windows" {
+		return ModeWindows
+	}
+	return ModePosix
+}
+
+var defaultParams struct {
+	once sync.Once
+	p    *Params
+	err  error
+
+	// bei init mit initMode geparst
+	early    *Params
+	earlyErr error
+}
+
+// initMode #Syntax, mit der init die Kommandozeile liest
+const initMode = ModeCompat
+
+// Parse #Argumente ohne Programmnamen, z.B. os.Args[1:], Syntax nach SetParseMode
+func Parse(args []string) (*Params, error) {
+	return ParseWith(args, GetParseMode())
+}
+
+// ParseWith #wie Parse mit eigener Options-Syntax
+func ParseWith(args []string, mode ParseMode) (*Params, error) {
+	var errs []string
+	args, err := expandResponseFiles(args, 0)
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	p, perrs := parseArgs(args, mode.resolve(), nil)
+	errs = append(errs, perrs...)
+
+	if len(errs) > 0 {
+		return p, errors.New(strings.Join(errs, "\n"))
+	}
+
+	return p, nil
+}
+
+// parseArgs #names: deklarierte Namen wie quiet, -quiet ist dann nicht -q mit Wert "uiet"
+func parseArgs(args []string, mode ParseMode, names map[string]bool) (*Params, []string) {
+	p := &Params{xargs: make(map[string]string), multi: make(map[string][]string), next: make(map[string]string),
+		args: args, mode: mode, names: names, vals: make(map[int]string), short: make(map[int]string)}
+
+	var errs []string
+	var prev, short string
+	optEnd := false
+	for _, v := range args {
+		if !optEnd && v == "--" {
+			optEnd = true
+			prev, short = "", ""
+			continue
+		}
+
+		if !optEnd && isOption(v, mode) {
+			var err error
+			if prev, short, err = p.option(v, mode); err != nil {
+				errs = append(errs, err.Error())
+			}
+		} else {
+			// auch "" und "-" (stdin) sind Positions-Parameter
+			p.pos = append(p.pos, v)
+			if len(prev) > 0 {
+				if len(p.xargs[prev]) == 0 {
+					p.xargs[prev] = v
+					p.multi[prev][len(p.multi[prev])-1] = v
+					p.vals[len(p.pos)-1] = prev
+				}
+			} else if len(short) > 0 {
+				p.next[short] = v
+				p.short[len(p.pos)-1] = short
+			}
+			prev, short = "", ""
+		}
+	}
+
+	p.ValueCheck("debug", "1")
+	return p, errs
+}
+
+// reparse #erneut mit deklarierten Namen parsen, falls einer davon als -qVALUE/-xVALUE gelesen wurde
+func (p *Params) reparse(names map[string]bool) *Params {
+	for _, k := range []string{"q", "x"} {
+		for _, v := range p.multi[k] {
+			if names[optionName(k+v, "=:")] {
+				np, _ := parseArgs(p.args, p.mode, names)
+				return np
+			}
+		}
+	}
+
+	return p
+}
+
+func isOption(v string, mode ParseMode) bool {
+	if len(v) < 2 {
+		return false
+	}
+
+	switch mode {
+	case ModeWindows:
+		return v[0] == '/'
+	case ModePosix:
+		// -5 ist eine Zahl
+		return v[0] == '-' && (v[1] < '0' || v[1] > '9')
+	}
+
+	return v[0] == '-' || v[0] == '/'
+}
+
+// option #liefert den Key, der einen folgenden Positions-Parameter als Wert erhalten kann,
+// short: ModePosix -abc, der folgende Positions-Parameter ist nur fuer ParamDefs ein Wert
+func (p *Params) option(v string, mode ParseMode) (key string, short string, err error) {
+	switch mode {
+	case ModeWindows:
+		// /q:VALUE und /q=VALUE wie /qVALUE
+		s := v[1:]
+		if len(s) > 1 && strings.IndexByte("qQxX", s[0]) >= 0 && strings.IndexByte("=:", s[1]) >= 0 {
+			s = s[:1] + s[2:]
+		}
+		key, err = p.keyValue(v, s, "=:", true)
+		return
+
+	case ModePosix:
+		if strings.HasPrefix(v, "--") {
+			key, err = p.keyValue(v, v[2:], "=", false)
+			return
+		}
+
+		if strings.Contains(v, "=") {
+			key, err = p.keyValue(v, v[1:], "=", false)
+			return
+		}
+
+		// -abc = -a -b -c
+		for _, r := range strings.ToLower(v[1:]) {
+			short = string(r)
+			p.add(short, "")
+		}
+		return "", short, nil
+	}
+
+	key, err = p.keyValue(v, v[1:], "=", true)
+	return
+}
+
+// keyValue #s ohne Prefix, sep: Trennzeichen zwischen Key und Wert, glue: -qVALUE und -xVALUE
+// (wie bisher unveraendert, -q=abc liefert "=abc")
+func (p *Params) keyValue(v string, s string, sep string, glue bool) (string, error) {
+	key := strings.ToLower(s[0:1])
+	if glue && (key == "q" || key == "x") && !p.names[optionName(s, sep)] {
+		p.add(key, s[1:])
+		return key, nil
+	}
+
+	ix := strings.IndexAny(s, sep)
+	if ix == 0 {
+		return "", errors.New("missing parameter name: " + v)
+	}
+
+	if ix > 0 {
+		key = strings.ToLower(s[:ix])
+		p.add(key, s[ix+1:])
+		return key, nil
+	}
+
+	key = strings.ToLower(s)
+	p.add(key, "")
+	return key, nil
+}
+
+// optionName #Name bis zum Trennzeichen, in Kleinbuchstaben
+func optionName(s string, sep string) string {
+	if ix := strings.IndexAny(s, sep); ix >= 0 {
+		s = s[:ix]
+	}
+	return strings.ToLower(s)
+}
+
+// add #wiederholte Parameter: xargs haelt den letzten Wert, multi alle
+func (p *Params) add(key string, val string) {
+	p.xargs[key] = val
+	p.multi[key] = append(p.multi[key], val)
+}
+
+// expandResponseFiles #@datei: ein Argument je Zeile, Leerzeilen und Zeilen mit # bzw. ; werden uebergangen;
+// fehlt die Datei, bleibt @datei Positions-Parameter (z.B. @handle), "--" beendet auch in einer Datei
+// die Ersetzung fuer alle folgenden Argumente
+func expandResponseFiles(args []string, depth int) ([]string, error) {
+	out, _, errs := expandArgs(args, depth)
+	if len(errs) > 0 {
+		return out, errors.New(strings.Join(errs, "\n"))
+	}
+	return out, nil
+}
+
+// expandArgs #end: "--" gefunden, der Rest bleibt unveraendert
+func expandArgs(args []string, depth int) (out []string, end bool, errs []string) {
+	for i, v := range args {
+		if v == "--" {
+			return append(out, args[i:]...), true, errs
+		}
+
+		if len(v) < 2 || v[0] != '@' {
+			out = append(out, v)
+			continue
+		}
+
+		if depth >= maxResponseDepth {
+			errs = append(errs, fmt.Sprintf("%s: response files nested too deep", v))
+			out = append(out, v)
+			continue
+		}
+
+		data, err := ioutil.ReadFile(v[1:])
+		if err != nil {
+			if !os.IsNotExist(err) {
+				errs = append(errs, err.Error())
+			}
+			out = append(out, v)
+			continue
+		}
+
+		var lines []string
+		for _, l := range strings.Split(string(data), "\n") {
+			l = strings.TrimSpace(l)
+			if len(l) == 0 || l[0] == '#' || l[0] == ';' {
+				continue
+			}
+			lines = append(lines, l)
+		}
+
+		lines, end, lerrs := expandArgs(lines, depth+1)
+		out = append(out, lines...)
+		errs = append(errs, lerrs...)
+		if end {
+			return append(out, args[i+1:]...), true, errs
+		}
+	}
+
+	return out, false, errs
+}
+
+// initParams #Global wie bisher schon bei init fuellen; @datei wird dabei nicht gelesen,
+// sondern erst beim ersten Zugriff auf DefaultParams
+func initParams() {
+	args := osArgs()
+	p, errs := parseArgs(args, initMode, nil)
+	setGlobalParams(p)
+
+	for _, v := range args {
+		if v == "--" {
+			break
+		}
+		if len(v) > 1 && v[0] == '@' {
+			return
+		}
+	}
+
+	defaultParams.early = p
+	if len(errs) > 0 {
+		defaultParams.earlyErr = errors.New(strings.Join(errs, "\n"))
+	}
+}
+
+// osArgs #os.Args ohne Programmnamen, -test.* Flags von go test werden ignoriert
+func osArgs() []string {
+	var args []string
+	if len(os.Args) > 1 {
+		for _, a := range os.Args[1:] {
+			if !strings.HasPrefix(a, "-test.") {
+				args = append(args, a)
+			}
+		}
+	}
+	return args
+}
+
+func setGlobalParams(p *Params) {
+	Global.Xargs = p.xargs
+	Global.xargsWithOut = p.pos
+	Global.Debug = p.AsInt("debug", 0)
+}
+
+// DefaultParams #os.Args, bei unveraendertem ParseMode die bei init geparsten Werte
+// (Aenderungen an Global.Xargs bleiben dann sichtbar)
+func DefaultParams() *Params {
+	defaultParams.once.Do(func() {
+		mode := freezeParseMode()
+
+		p, err := defaultParams.early, defaultParams.earlyErr
+		if p == nil || mode.resolve() != initMode {
+			p, err = ParseWith(osArgs(), mode)
+		}
+
+		defaultParams.p = p
+		defaultParams.err = err
+
+		setGlobalParams(p)
+		if Global.Debug > 0 {
+			defaultLog.SetLevel(LevelDebug)
+		}
+	})
+
+	return defaultParams.p
+}
+
+// DefaultParamsErr #Fehler beim Parsen von os.Args
+func DefaultParamsErr() error {
+	DefaultParams()
+	return defaultParams.err
+}
+
+// Args #Positions-Parameter
+func (p *Params) Args() []string {
+	return p.pos
+}
+
+// Map #alle -key=val Parameter, Keys in Kleinbuchstaben
+func (p *Params) Map() map[string]string {
+	return p.xargs
+}
+
+// Param #Positions-Parameter ix
+func (p *Params) Param(ix int, def string) string {
+	if ix >= len(p.pos) {
+		return def
+	}
+
+	return p.pos[ix]
+}
+
+// Lookup #Argumente, Umgebung (SetEnvPrefix), Konfigurationsdatei;
+// -key ohne Wert nimmt einen Wert aus Umgebung bzw. Datei
+func (p *Params) Lookup(sKey string) (v string, src ParamSource, ok bool) {
+	lKey := strings.ToLower(sKey)
+
+	if v, ok = p.xargs[lKey]; ok {
+		if len(v) == 0 {
+			if cv, csrc, cok := configLookup(lKey); cok && len(cv) > 0 {
+				return cv, csrc, true
+			}
+		}
+		return v, SourceArg, true
+	}
+
+	return configLookup(lKey)
+}
+
+// Value #wie ParamValue
+func (p *Params) Value(sKey string, def string) string {
+	v, _, ok := p.Lookup(sKey)
+	if !ok || len(v) == 0 {
+		return def
+	}
+
+	return v
+}
+
+// Exist #wie ParamExist
+func (p *Params) Exist(sKey string) (string, bool) {
+	v, _, ok := p.Lookup(sKey)
+	return v, ok
+}
+
+// KeyExist #
+func (p *Params) KeyExist(sKey string) bool {
+	_, ok := p.Exist(sKey)
+	return ok
+}
+
+// Exists #einer der Keys vorhanden
+func (p *Params) Exists(sKeys []string) bool {
+	for _, k := range sKeys {
+		if p.KeyExist(k) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValueExist #Key in Kleinbuchstaben und ob ein Wert angegeben ist
+func (p *Params) ValueExist(sKey string) (string, bool) {
+	v, _, ok := p.Lookup(sKey)
+	return strings.ToLower(sKey), ok && len(v) > 0
+}
+
+// AsInt #def auch bei ungueltigem Wert, siehe AsIntErr
+func (p *Params) AsInt(sKey string, def int) int {
+	i, _ := p.AsIntErr(sKey, def)
+	return i
+}
+
+// Values #alle Werte eines wiederholten Parameters (-file=a -file=b)
+func (p *Params) Values(sKey string) []string {
+	lKey := strings.ToLower(sKey)
+	if v, ok := p.multi[lKey]; ok {
+		return v
+	}
+
+	if v, _, ok := configLookup(lKey); ok {
+		return []string{v}
+	}
+
+	return nil
+}
+
+// Set #ersetzt alle Werte
+func (p *Params) Set(sKey string, val string) {
+	lKey := strings.ToLower(sKey)
+	p.xargs[lKey] = val
+	p.multi[lKey] = []string{val}
+}
+
+// ValueCheck #ohne Wert angegebener Parameter erhaelt def
+func (p *Params) ValueCheck(sKey string, def string) {
+	v, ok := p.Exist(sKey)
+
+	if ok && len(v) == 0 {
+		p.Set(sKey, def)
+	}
+}
+
+// Print #geheime Werte (siehe AddSecretParam) werden geschwaerzt
+func (p *Params) Print() {
+	secrets := make(map[string]bool)
+	for k, a := range p.multi {
+		for _, v := range a {
+			if len(v) > 0 && IsSecretParam(k) {
+				secrets[v] = true
+			}
+		}
+	}
+
+	fmt.Println("\n--> xParams:")
+	for i, v := range p.pos {
+		// "-pwd geheim" landet auch in den Positions-Parametern
+		if secrets[v] {
+			v = RedactMask
+		}
+		fmt.Printf("%d. [%s]\n", i, Redact(v))
+	}
+
+	fmt.Println("----------------------------")
+
+	var sk []string
+	for k := range p.xargs {
+		sk = append(sk, k)
+	}
+	sort.Strings(sk)
+
+	for _, k := range sk {
+		for _, v := range p.multi[k] {
+			fmt.Printf("%-16.16s: [%s]\n", k, RedactParam(k, v))
+		}
+	}
+	fmt.Print("\n\n")
+}
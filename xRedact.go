@@ -0,0 +1,282 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xRedact.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// RedactMask #Ersatz fuer geheime Werte
+const RedactMask = "***"
+
+type secretPattern struct {
+	name  string
+	re    *regexp.Regexp
+	check func(string) bool
+}
+
+var redact = struct {
+	sync.RWMutex
+	off      bool
+	params   map[string]bool
+	patterns []secretPattern  // wird nur ersetzt, nie veraendert
+	paramRe  []*regexp.Regexp // aus params, neu erzeugt von AddSecretParam
+}{params: make(map[string]bool)}
+
+func init() {
+	AddSecretParam("pwd", "password", "passwd", "pass", "token", "secret", "apikey", "api_key", "auth")
+
+	// Bearer/Basic-Token im Header-Format
+	AddSecretPattern("token", `(?i)\b(?:bearer|basic)\s+(?P<secret>[A-Za-z0-9\-._~+/]{8,}=*)`, nil)
+	// Benutzer:Passwort in URLs
+	AddSecretPattern("userinfo", `://[^/\s:@]+:(?P<secret>[^/\s@]+)@`, nil)
+	AddSecretPattern("iban", `\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]){11,30}\b`, validIBAN)
+	// 15-19 Ziffern bzw. 14 mit Diners-Prefix, am Stueck oder in Kartengruppen (4-4-4-4, 4-6-5),
+	// damit benachbarte EAN-Nummern nicht zu einer Kartennummer werden
+	AddSecretPattern("card", `\b(?:\d{14,19}|\d{4}(?: \d{4}){2,3}(?: \d{1,4})?|\d{4}(?:-\d{4}){2,3}(?:-\d{1,4})?|\d{4} \d{6} \d{4,5}|\d{4}-\d{6}-\d{4,5})\b`, validCard)
+}
+
+// SetRedact #Schwaerzen ein-/ausschalten (default ein)
+func SetRedact(on bool) {
+	redact.Lock()
+	redact.off = !on
+	redact.Unlock()
+}
+
+// AddSecretParam #Parameter-Namen, deren Werte nie ausgegeben werden
+func AddSecretParam(names ...string) {
+	redact.Lock()
+	defer redact.Unlock()
+
+	for _, n := range names {
+		redact.params[strings.ToLower(n)] = true
+	}
+	redact.paramRe = secretParamRe(redact.params)
+}
+
+// IsSecretParam #
+func IsSecretParam(name string) bool {
+	redact.RLock()
+	defer redact.RUnlock()
+	return redact.params[strings.ToLower(name)]
+}
+
+// AddSecretPattern #regulaerer Ausdruck fuer geheime Werte; gibt es die Gruppe "secret",
+// wird nur diese ersetzt. check kann Treffer nachpruefen (z.B. Pruefziffer), nil = alle
+func AddSecretPattern(name string, expr string, check func(string) bool) error {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return err
+	}
+
+	redact.Lock()
+	defer redact.Unlock()
+
+	p := secretPattern{name: name, re: re, check: check}
+	patterns := make([]secretPattern, 0, len(redact.patterns)+1)
+	replaced := false
+	for _, x := range redact.patterns {
+		if x.name == name {
+			x = p
+			replaced = true
+		}
+		patterns = append(patterns, x)
+	}
+
+	if !replaced {
+		patterns = append(patterns, p)
+	}
+	redact.patterns = patterns
+	return nil
+}
+
+// RemoveSecretPattern #
+func RemoveSecretPattern(name string) {
+	redact.Lock()
+	defer redact.Unlock()
+
+	patterns := make([]secretPattern, 0, len(redact.patterns))
+	for _, p := range redact.patterns {
+		if p.name != name {
+			patterns = append(patterns, p)
+		}
+	}
+	redact.patterns = patterns
+}
+
+// RedactParam #Wert eines Parameters fuer die Ausgabe
+func RedactParam(name string, value string) string {
+	if len(value) > 0 && IsSecretParam(name) && redactOn() {
+		return RedactMask
+	}
+	return Redact(value)
+}
+
+// Redact #ersetzt geheime Werte in s durch RedactMask
+func Redact(s string) string {
+	if len(s) == 0 || !redactOn() {
+		return s
+	}
+
+	redact.RLock()
+	paramRe := redact.paramRe
+	patterns := redact.patterns
+	redact.RUnlock()
+
+	for _, re := range paramRe {
+		s = replaceSecret(s, re, nil)
+	}
+	for _, p := range patterns {
+		s = replaceSecret(s, p.re, p.check)
+	}
+
+	return s
+}
+
+// RedactError #
+func RedactError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	s := err.Error()
+	if r := Redact(s); r != s {
+		return errors.New(r)
+	}
+	return err
+}
+
+func redactOn() bool {
+	redact.RLock()
+	defer redact.RUnlock()
+	return !redact.off
+}
+
+// secretParamRe #nur in Options- bzw. Schluesselform: -pwd=x, /pwd:x, pwd=x, ?token=x, "token": "x"
+// und als Option mit Leerzeichen: -pwd x, /pwd x; Text wie "auth: ok" bleibt sichtbar
+func secretParamRe(params map[string]bool) []*regexp.Regexp {
+	if len(params) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(params))
+	for n := range params {
+		names = append(names, regexp.QuoteMeta(n))
+	}
+
+	// laengere Namen zuerst, damit "password" nicht als "pass" endet
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+
+	alt := strings.Join(names, "|")
+	return []*regexp.Regexp{
+		regexp.MustCompile(`(?i)(?:^|[\s&?;,"{])(?:-{1,2}|/)?(?:` + alt + `)\s*=\s*"?(?P<secret>[^\s"&;,]+)`),
+		regexp.MustCompile(`(?i)(?:^|\s)(?:-{1,2}|/)(?:` + alt + `):(?P<secret>[^\s"&;,]+)`),
+		regexp.MustCompile(`(?i)"(?:` + alt + `)"\s*:\s*"?(?P<secret>[^\s"&;,]+)`),
+		regexp.MustCompile(`(?i)(?:^|\s)(?:-{1,2}|/)(?:` + alt + `)\s+(?P<secret>[^\s\-/]\S*)`),
+	}
+}
+
+func replaceSecret(s string, re *regexp.Regexp, check func(string) bool) string {
+	if re == nil {
+		return s
+	}
+
+	gi := re.SubexpIndex("secret")
+
+	var b strings.Builder
+	last := 0
+	for _, m := range re.FindAllStringSubmatchIndex(s, -1) {
+		from, to := m[0], m[1]
+		if gi > 0 && m[2*gi] >= 0 {
+			from, to = m[2*gi], m[2*gi+1]
+		}
+
+		if check != nil && !check(s[from:to]) {
+			continue
+		}
+
+		b.WriteString(s[last:from])
+		b.WriteString(RedactMask)
+		last = to
+	}
+
+	if last == 0 {
+		return s
+	}
+
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// validCard #15-19 Ziffern oder 14 mit Diners-Prefix (300-305, 36, 38), jeweils mit Luhn-Pruefziffer
+func validCard(s string) bool {
+	d := strings.NewReplacer(" ", "", "-", "").Replace(s)
+	switch {
+	case len(d) >= 15 && len(d) <= 19:
+	case len(d) == 14 && (d[:2] == "36" || d[:2] == "38" || (d[:2] == "30" && d[2] <= '5')):
+	default:
+		return false
+	}
+
+	return validLuhn(d)
+}
+
+// validLuhn #Pruefziffer von Kartennummern
+func validLuhn(s string) bool {
+	sum := 0
+	n := 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+
+	return n >= 13 && sum%10 == 0
+}
+
+// validIBAN #Pruefziffer mod 97
+func validIBAN(s string) bool {
+	s = strings.Replace(s, " ", "", -1)
+	if len(s) < 15 {
+		return false
+	}
+
+	s = s[4:] + s[:4]
+	rem := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			rem = (rem*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			rem = (rem*100 + int(c-'A'+10)) % 97
+		default:
+			return false
+		}
+	}
+
+	return rem == 1
+}
@@ -0,0 +1,660 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xSchedule.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule #Zeitplan aus Cron-Ausdruck oder Kurzform
+//
+//	cron:      "30 2 * * mo-fr", mit Sekunden "0 */5 * * * *", @hourly, @daily, @weekly, @monthly, @yearly
+//	Kurzform:  "mo,di,fr 12:00-18:00", "mo-fr 06:30,12:00", "sa 08:00-12:00/15" (alle 15 Minuten)
+//	Feiertage: "ho" bzw. "!ho" bei den Wochentagen, z.B. "0 6 * * mo-fr,!ho" oder "mo-sa,!ho 08:00"
+//
+// Kurzform: jede Minute innerhalb eines Fensters (inkl. Ende wie PermitHour), Zeitpunkte einzeln;
+// ein Fenster ueber Mitternacht gehoert zum Tag seines Beginns.
+// DST: eine feste Uhrzeit in der uebersprungenen Stunde laeuft direkt nach der Umstellung,
+// in der doppelten Stunde nur einmal; Ausdruecke mit Stunde * laufen in beiden.
+type Schedule struct {
+	expr     string
+	sec      uint64
+	mins     [1440/64 + 1]uint64 // Minute des Tages
+	late     [1440/64 + 1]uint64 // Minute nach Mitternacht, Fenster des Vortags
+	hasLate  bool
+	dom      uint64
+	month    uint16
+	dow      uint8
+	domStar  bool
+	dowStar  bool
+	hourStar bool
+	holiday  int // 1: auch an Feiertagen, -1: nie an Feiertagen
+	loc      *time.Location
+}
+
+// maximaler Suchzeitraum fuer Next/Prev
+const scheduleHorizon = 5 * 366 * 24 * time.Hour
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseSchedule #leerer Ausdruck ist ein Fehler
+func ParseSchedule(expr string) (*Schedule, error) {
+	s := &Schedule{expr: strings.TrimSpace(expr)}
+	if len(s.expr) == 0 {
+		return nil, fmt.Errorf("schedule: empty expression")
+	}
+
+	e := strings.ToLower(s.expr)
+	if m, ok := cronMacros[e]; ok {
+		e = m
+	}
+
+	f := strings.Fields(e)
+	var err error
+	if (len(f) == 5 || len(f) == 6) && !strings.Contains(e, ":") {
+		// "mo di mi do fr 1400-2200" hat auch 6 Felder: passt cron nicht, gilt das Kurzformat
+		if err = s.parseCron(f); err != nil {
+			c := &Schedule{expr: s.expr}
+			if cerr := c.parseCompact(e); cerr == nil {
+				s, err = c, nil
+			} else if f[0][0] >= 'a' && f[0][0] <= 'z' {
+				err = cerr
+			}
+		}
+	} else {
+		err = s.parseCompact(e)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("schedule %q: %v", expr, err)
+	}
+
+	return s, nil
+}
+
+// MustParseSchedule #panic bei Fehler, fuer feste Ausdruecke
+func MustParseSchedule(expr string) *Schedule {
+	s, err := ParseSchedule(expr)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// In #Zeitzone der Auswertung, ohne Angabe die von t
+func (s *Schedule) In(loc *time.Location) *Schedule {
+	c := *s
+	c.loc = loc
+	return &c
+}
+
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+func (s *Schedule) parseCron(f []string) error {
+	if len(f) == 5 {
+		f = append([]string{"0"}, f...)
+	}
+
+	sec, _, err := cronField(f[0], 0, 59)
+	if err != nil {
+		return fmt.Errorf("second: %v", err)
+	}
+	s.sec = sec
+
+	mins, _, err := cronField(f[1], 0, 59)
+	if err != nil {
+		return fmt.Errorf("minute: %v", err)
+	}
+
+	hours, hStar, err := cronField(f[2], 0, 23)
+	if err != nil {
+		return fmt.Errorf("hour: %v", err)
+	}
+	s.hourStar = hStar
+
+	for h := 0; h < 24; h++ {
+		if hours&(1<<uint(h)) == 0 {
+			continue
+		}
+		for m := 0; m < 60; m++ {
+			if mins&(1<<uint(m)) != 0 {
+				s.setMin(h*60 + m)
+			}
+		}
+	}
+
+	if s.dom, s.domStar, err = cronField(f[3], 1, 31); err != nil {
+		return fmt.Errorf("day of month: %v", err)
+	}
+
+	month, _, err := cronField(f[4], 1, 12)
+	if err != nil {
+		return fmt.Errorf("month: %v", err)
+	}
+	s.month = uint16(month)
+
+	var days []string
+	for _, d := range strings.Split(f[5], ",") {
+		if !s.holidayToken(d) {
+			days = append(days, d)
+		}
+	}
+
+	var dow uint64
+	dowStar := false
+	if len(days) > 0 {
+		if dow, dowStar, err = cronField(strings.Join(days, ","), 0, 7); err != nil {
+			return fmt.Errorf("day of week: %v", err)
+		}
+	} else if s.holiday < 0 {
+		dowStar = true
+		dow = 0x7f
+	}
+
+	// 7 = Sonntag
+	if dow&(1<<7) != 0 {
+		dow |= 1
+	}
+	s.dow = uint8(dow & 0x7f)
+	s.dowStar = dowStar
+
+	return nil
+}
+
+// cronField #*, */n, a-b, a-b/n, a,b; Monate und Wochentage auch als Namen (siehe RegisterLocale)
+func cronField(f string, min, max int) (bits uint64, star bool, err error) {
+	for _, part := range strings.Split(f, ",") {
+		step := 1
+		if ix := strings.Index(part, "/"); ix >= 0 {
+			if step, err = strconv.Atoi(part[ix+1:]); err != nil || step < 1 {
+				return 0, false, fmt.Errorf("invalid step %q", part)
+			}
+			part = part[:ix]
+		}
+
+		lo, hi := min, max
+		switch {
+		case part == "*" || part == "?":
+			star = star || step == 1
+		case strings.Contains(part, "-"):
+			ix := strings.Index(part, "-")
+			if lo, err = cronValue(part[:ix], min, max); err != nil {
+				return 0, false, err
+			}
+			if hi, err = cronValue(part[ix+1:], min, max); err != nil {
+				return 0, false, err
+			}
+			if hi < lo {
+				return 0, false, fmt.Errorf("invalid range %q", part)
+			}
+		default:
+			if lo, err = cronValue(part, min, max); err != nil {
+				return 0, false, err
+			}
+			hi = lo
+			if step > 1 {
+				hi = max
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, star, nil
+}
+
+func cronValue(s string, min, max int) (int, error) {
+	if max == 12 {
+		if m, ok := monthToken(s); ok {
+			return int(m), nil
+		}
+	}
+
+	if max == 7 {
+		if wd, ok := weekdayToken(s); ok {
+			return int(wd), nil
+		}
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil || v < min || v > max {
+		return 0, fmt.Errorf("invalid value %q (%d-%d)", s, min, max)
+	}
+
+	return v, nil
+}
+
+// parseCompact #Wochentage und Zeiten/Fenster, getrennt durch Leerzeichen oder Komma
+func (s *Schedule) parseCompact(e string) error {
+	s.sec = 1
+	s.dom = ^uint64(0)
+	s.domStar = true
+	s.month = 0xffff
+
+	var days uint8
+	hasTime := false
+
+	toks := strings.FieldsFunc(e, func(r rune) bool { return r == ' ' || r == ',' || r == ';' })
+	if len(toks) == 0 {
+		return fmt.Errorf("empty expression")
+	}
+
+	for _, tok := range toks {
+		if d, ok := weekdayRange(tok); ok {
+			days |= d
+			continue
+		}
+
+		if s.holidayToken(tok) {
+			continue
+		}
+
+		from, to, step, err := parseClockRange(tok)
+		if err != nil {
+			return err
+		}
+		hasTime = true
+
+		// Fenster ueber Mitternacht gehoert zum Tag seines Beginns
+		if to < from {
+			to += 1440
+		}
+		for m := from; m <= to; m += step {
+			if m < 1440 {
+				s.setMin(m)
+			} else {
+				s.late[(m-1440)/64] |= 1 << uint((m-1440)%64)
+				s.hasLate = true
+			}
+		}
+	}
+
+	// nur "ho": ausschliesslich an Feiertagen
+	if days == 0 && s.holiday <= 0 {
+		days = 0x7f
+		s.dowStar = true
+	}
+	s.dow = days
+
+	if !hasTime {
+		for m := 0; m < 1440; m++ {
+			s.setMin(m)
+		}
+		s.hourStar = true
+	}
+
+	return nil
+}
+
+// holidayToken #"ho" bzw. "!ho"
+func (s *Schedule) holidayToken(tok string) bool {
+	switch tok {
+	case holidayToken:
+		s.holiday = 1
+	case "!" + holidayToken:
+		s.holiday = -1
+	default:
+		return false
+	}
+
+	return true
+}
+
+// weekdayRange #"mo", "mo-fr", "so-di"
+func weekdayRange(tok string) (uint8, bool) {
+	if wd, ok := weekdayToken(tok); ok {
+		return 1 << uint(wd), true
+	}
+
+	ix := strings.Index(tok, "-")
+	if ix < 0 {
+		return 0, false
+	}
+
+	a, ok1 := weekdayToken(tok[:ix])
+	b, ok2 := weekdayToken(tok[ix+1:])
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+
+	var bits uint8
+	for d := int(a); ; d = (d + 1) % 7 {
+		bits |= 1 << uint(d)
+		if d == int(b) {
+			break
+		}
+	}
+
+	return bits, true
+}
+
+// parseClockRange #"12:00", "12:00-18:00", "1400-2200", "12:00-18:00/15"; Minuten des Tages
+func parseClockRange(tok string) (from, to, step int, err error) {
+	step = 1
+	if ix := strings.Index(tok, "/"); ix >= 0 {
+		if step, err = strconv.Atoi(tok[ix+1:]); err != nil || step < 1 {
+			return 0, 0, 0, fmt.Errorf("invalid step %q", tok)
+		}
+		tok = tok[:ix]
+	}
+
+	parts := strings.Split(tok, "-")
+	if len(parts) > 2 {
+		return 0, 0, 0, fmt.Errorf("invalid time range %q", tok)
+	}
+
+	if from, err = parseClock(parts[0]); err != nil {
+		return
+	}
+
+	to = from
+	if len(parts) == 2 {
+		to, err = parseClock(parts[1])
+	}
+
+	return
+}
+
+// parseClock #"12:00", "1200", "24:00" -> Minute des Tages
+func parseClock(s string) (int, error) {
+	v, err := parseDaySecond(s)
+	if err != nil {
+		return 0, err
+	}
+
+	if v%60 != 0 {
+		return 0, fmt.Errorf("invalid time %q: seconds not allowed", s)
+	}
+
+	if v == daySeconds {
+		return 1439, nil
+	}
+
+	return v / 60, nil
+}
+
+func (s *Schedule) setMin(m int) {
+	s.mins[m/64] |= 1 << uint(m%64)
+}
+
+// hasMin #own: Minuten des Tages, late: Minuten aus einem Fenster des Vortags
+func (s *Schedule) hasMin(m int, own, late bool) bool {
+	return (own && s.mins[m/64]&(1<<uint(m%64)) != 0) ||
+		(late && s.late[m/64]&(1<<uint(m%64)) != 0)
+}
+
+// nextMin #naechste gesetzte Minute >= m, -1 wenn keine
+func (s *Schedule) nextMin(m int, own, late bool) int {
+	for ; m < 1440; m++ {
+		if s.hasMin(m, own, late) {
+			return m
+		}
+	}
+	return -1
+}
+
+// prevMin #letzte gesetzte Minute <= m, -1 wenn keine
+func (s *Schedule) prevMin(m int, own, late bool) int {
+	for ; m >= 0; m-- {
+		if s.hasMin(m, own, late) {
+			return m
+		}
+	}
+	return -1
+}
+
+func (s *Schedule) in(t time.Time) time.Time {
+	if s.loc != nil {
+		return t.In(s.loc)
+	}
+	return t
+}
+
+func (s *Schedule) monthOK(t time.Time) bool {
+	return s.month&(1<<uint(t.Month())) != 0
+}
+
+func (s *Schedule) dayOK(t time.Time) bool {
+	if !s.monthOK(t) {
+		return false
+	}
+
+	if s.holiday != 0 && IsHoliday(t) {
+		return s.holiday > 0
+	}
+
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+
+	// cron: sind beide eingeschraenkt, reicht einer
+	if !s.domStar && !s.dowStar {
+		return dom || dow
+	}
+
+	return dom && dow
+}
+
+// daySets #gelten am Kalendertag von t die eigenen Minuten bzw. die des Vortags
+func (s *Schedule) daySets(t time.Time) (own, late bool) {
+	own = s.dayOK(t)
+	if s.hasLate {
+		late = s.dayOK(time.Date(t.Year(), t.Month(), t.Day()-1, 12, 0, 0, 0, t.Location()))
+	}
+	return
+}
+
+func wallMin(t time.Time) int {
+	return t.Hour()*60 + t.Minute()
+}
+
+// repeatedWall #t liegt in der zweiten Haelfte einer doppelten Stunde (Ende Sommerzeit)
+func repeatedWall(t time.Time) bool {
+	_, off := t.Zone()
+	_, before := t.Add(-3 * time.Hour).Zone()
+	if before <= off {
+		return false
+	}
+
+	e := t.Add(-time.Duration(before-off) * time.Second)
+	return e.Day() == t.Day() && wallMin(e) == wallMin(t)
+}
+
+// minuteFires #m ist minutengenau
+func (s *Schedule) minuteFires(m time.Time) bool {
+	own, late := s.daySets(m)
+	if !own && !late {
+		return false
+	}
+
+	wm := wallMin(m)
+	if s.hasMin(wm, own, late) {
+		return s.hourStar || !repeatedWall(m)
+	}
+
+	// Beginn der Sommerzeit: Zeiten in der uebersprungenen Stunde laufen jetzt
+	if s.hourStar {
+		return false
+	}
+
+	p := m.Add(-time.Minute)
+	if p.Day() == m.Day() {
+		for x := wallMin(p) + 1; x < wm; x++ {
+			if s.hasMin(x, own, late) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (s *Schedule) firstSec(after int) int {
+	for x := after; x < 60; x++ {
+		if s.sec&(1<<uint(x)) != 0 {
+			return x
+		}
+	}
+	return -1
+}
+
+func (s *Schedule) lastSec(before int) int {
+	for x := before; x >= 0; x-- {
+		if s.sec&(1<<uint(x)) != 0 {
+			return x
+		}
+	}
+	return -1
+}
+
+// Matches #t (sekundengenau) ist ein Ausfuehrungszeitpunkt
+func (s *Schedule) Matches(t time.Time) bool {
+	t = s.in(t)
+	if s.sec&(1<<uint(t.Second())) == 0 {
+		return false
+	}
+
+	return s.minuteFires(t.Truncate(time.Minute))
+}
+
+// Next #erster Zeitpunkt nach t, Zero wenn keiner innerhalb von 5 Jahren
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = s.in(t)
+	m := t.Truncate(time.Minute)
+
+	if s.minuteFires(m) {
+		if x := s.firstSec(t.Second() + 1); x >= 0 && m.Add(time.Duration(x)*time.Second).After(t) {
+			return m.Add(time.Duration(x) * time.Second)
+		}
+	}
+
+	end := t.Add(scheduleHorizon)
+	loc := m.Location()
+	for m = m.Add(time.Minute); m.Before(end); {
+		if !s.monthOK(m) {
+			m = nextAfter(m, time.Date(m.Year(), m.Month()+1, 1, 0, 0, 0, 0, loc))
+			continue
+		}
+
+		own, late := s.daySets(m)
+		if !own && !late {
+			m = nextAfter(m, time.Date(m.Year(), m.Month(), m.Day()+1, 0, 0, 0, 0, loc))
+			continue
+		}
+
+		if s.minuteFires(m) {
+			return m.Add(time.Duration(s.firstSec(0)) * time.Second)
+		}
+
+		wm := wallMin(m)
+		nx := s.nextMin(wm+1, own, late)
+		if nx < 0 {
+			m = nextAfter(m, time.Date(m.Year(), m.Month(), m.Day()+1, 0, 0, 0, 0, loc))
+			continue
+		}
+
+		// Sprung nur ohne Zeitumstellung, sonst minutenweise
+		c := m.Add(time.Duration(nx-wm) * time.Minute)
+		if zoneOffset(c) == zoneOffset(m) && c.Day() == m.Day() {
+			m = c
+		} else {
+			m = m.Add(time.Minute)
+		}
+	}
+
+	return time.Time{}
+}
+
+// Prev #letzter Zeitpunkt vor t, Zero wenn keiner innerhalb von 5 Jahren
+func (s *Schedule) Prev(t time.Time) time.Time {
+	t = s.in(t)
+	m := t.Truncate(time.Minute)
+
+	if s.minuteFires(m) && t.After(m) {
+		last := t.Second()
+		if t.Nanosecond() == 0 {
+			last--
+		}
+		if x := s.lastSec(last); x >= 0 {
+			return m.Add(time.Duration(x) * time.Second)
+		}
+	}
+
+	end := t.Add(-scheduleHorizon)
+	loc := m.Location()
+	for m = m.Add(-time.Minute); m.After(end); {
+		if !s.monthOK(m) {
+			m = prevBefore(m, time.Date(m.Year(), m.Month(), 1, 0, 0, 0, 0, loc).Add(-time.Minute))
+			continue
+		}
+
+		own, late := s.daySets(m)
+		if !own && !late {
+			m = prevBefore(m, time.Date(m.Year(), m.Month(), m.Day(), 0, 0, 0, 0, loc).Add(-time.Minute))
+			continue
+		}
+
+		if s.minuteFires(m) {
+			return m.Add(time.Duration(s.lastSec(59)) * time.Second)
+		}
+
+		// die erste Haelfte einer doppelten Stunde liegt vor der Umstellung, daher
+		// Spruenge nur ohne Zeitumstellung, sonst minutenweise
+		wm := wallMin(m)
+		c := time.Date(m.Year(), m.Month(), m.Day(), 0, 0, 0, 0, loc).Add(-time.Minute)
+		if pv := s.prevMin(wm-1, own, late); pv >= 0 {
+			c = m.Add(-time.Duration(wm-pv) * time.Minute)
+		}
+
+		if zoneOffset(c) == zoneOffset(m) && c.Before(m) {
+			m = c.Truncate(time.Minute)
+		} else {
+			m = m.Add(-time.Minute)
+		}
+	}
+
+	return time.Time{}
+}
+
+func zoneOffset(t time.Time) int {
+	_, off := t.Zone()
+	return off
+}
+
+// nextAfter #n, mindestens aber eine Minute nach m
+func nextAfter(m, n time.Time) time.Time {
+	if !n.After(m) {
+		return m.Add(time.Minute)
+	}
+	return n.Truncate(time.Minute)
+}
+
+// prevBefore #n, hoechstens aber eine Minute vor m
+func prevBefore(m, n time.Time) time.Time {
+	if !n.Before(m) {
+		return m.Add(-time.Minute)
+	}
+	return n.Truncate(time.Minute)
+}
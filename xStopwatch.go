@@ -0,0 +1,203 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xStopwatch.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Stopwatch #benannte, verschachtelbare Phasen; wiederholte Phasen werden zusammengefasst
+//
+//	sw := NewStopwatch("import")
+//	defer sw.Log(nil)
+//	stop := sw.Start("lesen")
+//	...
+//	stop()
+//
+// Start/Stop verschachteln nach der Reihenfolge der Aufrufe und sind daher fuer eine Goroutine
+// gedacht; parallele Goroutinen verwenden je eine eigene Stopwatch.
+type Stopwatch struct {
+	mu     sync.Mutex
+	name   string
+	start  time.Time
+	open   []openPhase
+	phases map[string]*PhaseStat
+	order  []string
+	runs   uint64
+}
+
+type openPhase struct {
+	path  string
+	start time.Time
+	run   uint64 // Durchgang, fuer die Stop-Funktion von Start
+}
+
+// PhaseStat #Name ist der Pfad der Phase, verschachtelt mit "/"
+type PhaseStat struct {
+	Name  string
+	Depth int
+	Count int
+	Total time.Duration
+	Min   time.Duration
+	Max   time.Duration
+}
+
+// Avg #
+func (p PhaseStat) Avg() time.Duration {
+	if p.Count == 0 {
+		return 0
+	}
+	return p.Total / time.Duration(p.Count)
+}
+
+// NewStopwatch #
+func NewStopwatch(name string) *Stopwatch {
+	return &Stopwatch{name: name, start: Now(), phases: make(map[string]*PhaseStat)}
+}
+
+// Start #neue Phase unterhalb der zuletzt gestarteten offenen Phase; liefert die Stop-Funktion
+func (s *Stopwatch) Start(phase string) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := phase
+	if n := len(s.open); n > 0 {
+		path = s.open[n-1].path + "/" + phase
+	}
+
+	if _, ok := s.phases[path]; !ok {
+		s.phases[path] = &PhaseStat{Name: path, Depth: strings.Count(path, "/")}
+		s.order = append(s.order, path)
+	}
+
+	s.runs++
+	run := s.runs
+	s.open = append(s.open, openPhase{path: path, start: Now(), run: run})
+
+	// beendet nur diesen Durchgang, ein spaeterer Start derselben Phase bleibt offen
+	return func() { s.stopAt(func(o openPhase) bool { return o.run == run }) }
+}
+
+// Stop #beendet die zuletzt gestartete offene Phase mit diesem Namen und alle darin noch offenen
+func (s *Stopwatch) Stop(phase string) {
+	s.stopAt(func(o openPhase) bool { return o.path == phase || strings.HasSuffix(o.path, "/"+phase) })
+}
+
+// stopAt #beendet die zuletzt gestartete offene Phase, fuer die match gilt, und alle darin offenen
+func (s *Stopwatch) stopAt(match func(o openPhase) bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := Now()
+	for i := len(s.open) - 1; i >= 0; i-- {
+		if !match(s.open[i]) {
+			continue
+		}
+
+		for j := len(s.open) - 1; j >= i; j-- {
+			s.record(s.open[j].path, now.Sub(s.open[j].start))
+		}
+		s.open = s.open[:i]
+		return
+	}
+}
+
+func (s *Stopwatch) record(path string, d time.Duration) {
+	p := s.phases[path]
+	if p.Count == 0 || d < p.Min {
+		p.Min = d
+	}
+	if d > p.Max {
+		p.Max = d
+	}
+	p.Count++
+	p.Total += d
+}
+
+// Elapsed #seit NewStopwatch
+func (s *Stopwatch) Elapsed() time.Duration {
+	return Since(s.start)
+}
+
+// Phases #in der Reihenfolge des ersten Starts, offene Phasen ohne laufenden Durchgang
+func (s *Stopwatch) Phases() []PhaseStat {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ps := make([]PhaseStat, 0, len(s.order))
+	for _, path := range s.order {
+		ps = append(ps, *s.phases[path])
+	}
+
+	return ps
+}
+
+// Report #Tabelle der Phasen, eine Zeile je Phase
+func (s *Stopwatch) Report(b *Buffer) {
+	fd := func(d time.Duration) string { return FormatDuration(d, DurationClock, true) }
+
+	b.WriteLine(fmt.Sprintf("Stopwatch %s: %s", s.name, fd(s.Elapsed())))
+	b.WriteLine(fmt.Sprintf("%-30s %6s %14s %14s %14s %14s", "Phase", "Count", "Total", "Min", "Max", "Avg"))
+
+	for _, p := range s.Phases() {
+		name := p.Name[strings.LastIndex(p.Name, "/")+1:]
+		name = strings.Repeat("  ", p.Depth) + name
+		b.WriteLine(fmt.Sprintf("%-30.30s %6d %14s %14s %14s %14s", name, p.Count, fd(p.Total), fd(p.Min), fd(p.Max), fd(p.Avg())))
+	}
+}
+
+// Log #Report zeilenweise in l, nil = DefaultLogger
+func (s *Stopwatch) Log(l *Logger) {
+	if l == nil {
+		l = defaultLog
+	}
+
+	var b Buffer
+	s.Report(&b)
+
+	var line string
+	for b.ReadLine(&line) == nil {
+		l.Log(line)
+	}
+}
+
+type phaseJSON struct {
+	Name    string  `json:"name"`
+	Depth   int     `json:"depth"`
+	Count   int     `json:"count"`
+	TotalMS float64 `json:"total_ms"`
+	MinMS   float64 `json:"min_ms"`
+	MaxMS   float64 `json:"max_ms"`
+	AvgMS   float64 `json:"avg_ms"`
+}
+
+// MarshalJSON #Zeiten in Millisekunden
+func (s *Stopwatch) MarshalJSON() ([]byte, error) {
+	ms := func(d time.Duration) float64 { return float64(d) / float64(time.Millisecond) }
+
+	out := struct {
+		Name      string      `json:"name"`
+		Start     time.Time   `json:"start"`
+		ElapsedMS float64     `json:"elapsed_ms"`
+		Phases    []phaseJSON `json:"phases"`
+	}{Name: s.name, Start: s.start, ElapsedMS: ms(s.Elapsed())}
+
+	for _, p := range s.Phases() {
+		out.Phases = append(out.Phases, phaseJSON{Name: p.Name, Depth: p.Depth, Count: p.Count,
+			TotalMS: ms(p.Total), MinMS: ms(p.Min), MaxMS: ms(p.Max), AvgMS: ms(p.Avg())})
+	}
+
+	return json.Marshal(out)
+}
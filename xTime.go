@@ -0,0 +1,97 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xTime.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"strings"
+	"time"
+)
+
+const logTimeLayout = "2006-01-02 15:04:05"
+
+// LogTimeFormat #Zeitstempel-Optionen fuer Log-Zeilen und Log-Dateinamen
+type LogTimeFormat struct {
+	Layout    string         // Go-Layout, leer = "2006-01-02 15:04:05"
+	Location  *time.Location // Zeitzone der Zeitstempel, nil = time.Local
+	Precision int            // Nachkommastellen der Sekunden (0..9)
+	Rollover  *time.Location // Zeitzone fuer den Tageswechsel der Log-Datei, nil = Location
+}
+
+// DefaultLogTimeFormat #entspricht STime/FTime
+var DefaultLogTimeFormat = LogTimeFormat{Layout: logTimeLayout}
+
+// UTCLogTimeFormat #Zeitstempel und Tageswechsel in UTC, Millisekunden
+var UTCLogTimeFormat = LogTimeFormat{Layout: "2006-01-02 15:04:05Z07:00", Location: time.UTC, Precision: 3}
+
+func (f LogTimeFormat) layout() string {
+	layout := f.Layout
+	if len(layout) == 0 {
+		layout = logTimeLayout
+	}
+
+	p := f.Precision
+	if p > 9 {
+		p = 9
+	}
+
+	if p > 0 && !strings.Contains(layout, "05.") {
+		layout = strings.Replace(layout, "05", "05."+strings.Repeat("0", p), 1)
+	}
+
+	return layout
+}
+
+func (f LogTimeFormat) location() *time.Location {
+	if f.Location == nil {
+		return time.Local
+	}
+	return f.Location
+}
+
+func (f LogTimeFormat) rollover() *time.Location {
+	if f.Rollover == nil {
+		return f.location()
+	}
+	return f.Rollover
+}
+
+// stampLen #Laenge des Zeitstempels am Zeilenanfang
+func (f LogTimeFormat) stampLen() int {
+	return len(time.Date(2006, 1, 2, 15, 4, 5, 0, f.location()).Format(f.layout()))
+}
+
+// STimeOpt #wie STime, aber mit Layout, Zeitzone und Genauigkeit aus f
+func STimeOpt(t time.Time, f LogTimeFormat) string {
+	return t.In(f.location()).Format(f.layout()) + " "
+}
+
+// FTimeOpt #wie FTime fuer t, in der Rollover-Zeitzone aus f
+func FTimeOpt(t time.Time, f LogTimeFormat) string {
+	return FormatTime(t.In(f.rollover()), "YYYYMMDDhhmmss")
+}
+
+// logFilePath #<dir>/yyyy/mm/<pfx>yyyymmdd.log, Tageswechsel in der Rollover-Zeitzone aus f
+func logFilePath(dir string, pfx string, t time.Time, f LogTimeFormat) (string, string) {
+	t = t.In(f.rollover())
+	mDir := PathJoin(dir, FormatTime(t, "YYYY"), FormatTime(t, "MM"))
+	return mDir, PathJoin(mDir, pfx+FormatTime(t, "YYYYMMDD")+".log")
+}
+
+// parseLogTime #Zeitstempel am Zeilenanfang lesen
+func (f LogTimeFormat) parseLogTime(s string) (time.Time, bool) {
+	n := f.stampLen()
+	if len(s) < n {
+		return time.Time{}, false
+	}
+
+	t, err := time.ParseInLocation(f.layout(), s[:n], f.location())
+	return t, err == nil
+}
@@ -0,0 +1,347 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xTimePattern.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Platzhalter fuer FormatTime und ParseTime, laengere zuerst; Text in '...' bleibt unveraendert.
+// Ein Wort (Folge von Buchstaben) gilt nur dann als Platzhalter, wenn es ganz aus Platzhaltern
+// besteht: "hh:mm Uhr" und "Datum: DD.MM.YYYY" bleiben Text, "YYYYMMDD" nicht; im Zweifel '...' verwenden.
+//
+//	YYYY YY       Jahr
+//	MMMM MMM      Monatsname, Kuerzel (Default-Locale)
+//	MM M          Monat 01..12, 1..12
+//	DD D          Tag 01..31, 1..31
+//	dddd ddd      Wochentag, Kuerzel (Default-Locale)
+//	hh h          Stunde 00..23, 0..23
+//	mm ss         Minute, Sekunde
+//	f..fffffffff  Sekundenbruchteile, nur nach "." bzw. ","
+var timeTokens = []string{"YYYY", "MMMM", "dddd", "MMM", "ddd", "YY", "MM", "DD", "hh", "mm", "ss", "M", "D", "h"}
+
+type patElem struct {
+	tok string // leer = Text
+	lit string
+}
+
+var timePatterns = struct {
+	sync.RWMutex
+	m map[string][]patElem
+}{m: make(map[string][]patElem)}
+
+// compileTimePattern #kompilierte Muster werden zwischengespeichert
+func compileTimePattern(pattern string) []patElem {
+	timePatterns.RLock()
+	p, ok := timePatterns.m[pattern]
+	timePatterns.RUnlock()
+	if ok {
+		return p
+	}
+
+	p = parseTimePattern(pattern)
+
+	timePatterns.Lock()
+	timePatterns.m[pattern] = p
+	timePatterns.Unlock()
+
+	return p
+}
+
+func parseTimePattern(pattern string) []patElem {
+	var p []patElem
+	lit := func(s string) {
+		if n := len(p); n > 0 && len(p[n-1].tok) == 0 {
+			p[n-1].lit += s
+			return
+		}
+		p = append(p, patElem{lit: s})
+	}
+
+	for s := pattern; len(s) > 0; {
+		if s[0] == '\'' {
+			ix := strings.IndexByte(s[1:], '\'')
+			if ix < 0 {
+				lit(s[1:])
+				break
+			}
+			if ix == 0 {
+				lit("'")
+			} else {
+				lit(s[1 : ix+1])
+			}
+			s = s[ix+2:]
+			continue
+		}
+
+		if s[0] == 'f' && afterDecimal(p) {
+			n := 1
+			for n < len(s) && n < 9 && s[n] == 'f' {
+				n++
+			}
+			p = append(p, patElem{tok: s[:n]})
+			s = s[n:]
+			continue
+		}
+
+		if n := readLetters(s); n > 0 {
+			if toks, ok := wordTokens(s[:n]); ok {
+				for _, t := range toks {
+					p = append(p, patElem{tok: t})
+				}
+			} else {
+				lit(s[:n])
+			}
+			s = s[n:]
+			continue
+		}
+
+		_, n := utf8.DecodeRuneInString(s)
+		lit(s[:n])
+		s = s[n:]
+	}
+
+	return p
+}
+
+// wordTokens #zerlegt ein Wort vollstaendig in Platzhalter, sonst ok = false
+func wordTokens(w string) (toks []string, ok bool) {
+	for len(w) > 0 {
+		tok := ""
+		for _, t := range timeTokens {
+			if strings.HasPrefix(w, t) {
+				tok = t
+				break
+			}
+		}
+
+		if len(tok) == 0 {
+			return nil, false
+		}
+
+		toks = append(toks, tok)
+		w = w[len(tok):]
+	}
+
+	return toks, true
+}
+
+// afterDecimal #Bruchteile nur direkt nach "." bzw. ",", z.B. "ss.fff"
+func afterDecimal(p []patElem) bool {
+	n := len(p)
+	if n == 0 || len(p[n-1].tok) > 0 {
+		return false
+	}
+
+	return strings.HasSuffix(p[n-1].lit, ".") || strings.HasSuffix(p[n-1].lit, ",")
+}
+
+// FormatTime #z.B. FormatTime(t, "YYYYMMDD_hhmmss"), FormatTime(t, "DD.MM.YYYY")
+func FormatTime(t time.Time, pattern string) string {
+	var sb strings.Builder
+
+	for _, e := range compileTimePattern(pattern) {
+		switch e.tok {
+		case "":
+			sb.WriteString(e.lit)
+		case "YYYY":
+			fmt.Fprintf(&sb, "%04d", t.Year())
+		case "YY":
+			fmt.Fprintf(&sb, "%02d", t.Year()%100)
+		case "MMMM":
+			sb.WriteString(defaultLocale().Month(t.Month()))
+		case "MMM":
+			sb.WriteString(defaultLocale().MonthsShort[t.Month()-1])
+		case "MM":
+			fmt.Fprintf(&sb, "%02d", t.Month())
+		case "M":
+			sb.WriteString(strconv.Itoa(int(t.Month())))
+		case "DD":
+			fmt.Fprintf(&sb, "%02d", t.Day())
+		case "D":
+			sb.WriteString(strconv.Itoa(t.Day()))
+		case "dddd":
+			sb.WriteString(defaultLocale().Weekday(t.Weekday()))
+		case "ddd":
+			sb.WriteString(defaultLocale().DaysShort[t.Weekday()])
+		case "hh":
+			fmt.Fprintf(&sb, "%02d", t.Hour())
+		case "h":
+			sb.WriteString(strconv.Itoa(t.Hour()))
+		case "mm":
+			fmt.Fprintf(&sb, "%02d", t.Minute())
+		case "ss":
+			fmt.Fprintf(&sb, "%02d", t.Second())
+		default:
+			sb.WriteString(fmt.Sprintf("%09d", t.Nanosecond())[:len(e.tok)])
+		}
+	}
+
+	return sb.String()
+}
+
+// ParseTime #Gegenstueck zu FormatTime in time.Local
+func ParseTime(s string, pattern string) (time.Time, error) {
+	return ParseTimeIn(s, pattern, time.Local)
+}
+
+// ParseTimeIn #Monats- und Tagesnamen in jeder registrierten Locale
+func ParseTimeIn(s string, pattern string, loc *time.Location) (time.Time, error) {
+	in := s
+	y, mo, d := 1, 1, 1
+	var h, mi, sec, ns int
+	wd := -1
+
+	fail := func() (time.Time, error) {
+		return time.Time{}, fmt.Errorf("time %q does not match %q", in, pattern)
+	}
+
+	for _, e := range compileTimePattern(pattern) {
+		var v, n int
+		ok := true
+
+		switch e.tok {
+		case "":
+			if !strings.HasPrefix(s, e.lit) {
+				return fail()
+			}
+			n = len(e.lit)
+		case "YYYY":
+			v, n, ok = readDigits(s, 4, 4)
+			y = v
+		case "YY":
+			v, n, ok = readDigits(s, 2, 2)
+			y = 2000 + v
+			if v >= 70 {
+				y = 1900 + v
+			}
+		case "MMMM", "MMM":
+			n = readLetters(s)
+			var m time.Month
+			m, ok = monthToken(s[:n])
+			mo = int(m)
+		case "MM", "M":
+			v, n, ok = readDigits(s, len(e.tok), 2)
+			mo = v
+		case "DD", "D":
+			v, n, ok = readDigits(s, len(e.tok), 2)
+			d = v
+		case "dddd", "ddd":
+			n = readLetters(s)
+			var w time.Weekday
+			w, ok = weekdayToken(s[:n])
+			wd = int(w)
+		case "hh", "h":
+			v, n, ok = readDigits(s, len(e.tok), 2)
+			h = v
+		case "mm":
+			v, n, ok = readDigits(s, 2, 2)
+			mi = v
+		case "ss":
+			v, n, ok = readDigits(s, 2, 2)
+			sec = v
+		default:
+			v, n, ok = readDigits(s, len(e.tok), len(e.tok))
+			ns = v
+			for i := len(e.tok); i < 9; i++ {
+				ns *= 10
+			}
+		}
+
+		if !ok || n == 0 {
+			return fail()
+		}
+		s = s[n:]
+	}
+
+	if len(s) > 0 || mo < 1 || mo > 12 || h > 23 || mi > 59 || sec > 59 {
+		return fail()
+	}
+
+	t := time.Date(y, time.Month(mo), d, h, mi, sec, ns, loc)
+	if t.Day() != d || int(t.Month()) != mo {
+		return time.Time{}, fmt.Errorf("invalid date %q", in)
+	}
+
+	// "Mo, 7.3.2020": der 7.3.2020 ist ein Samstag
+	if wd >= 0 && int(t.Weekday()) != wd {
+		return time.Time{}, fmt.Errorf("weekday does not match date %q", in)
+	}
+
+	return t, nil
+}
+
+func readDigits(s string, min, max int) (v int, n int, ok bool) {
+	for n < len(s) && n < max && s[n] >= '0' && s[n] <= '9' {
+		v = v*10 + int(s[n]-'0')
+		n++
+	}
+	return v, n, n >= min
+}
+
+func readLetters(s string) int {
+	n := 0
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if !unicode.IsLetter(r) {
+			break
+		}
+		n += size
+	}
+	return n
+}
+
+// GermanDatePatterns #Eingabeformate fuer ParseGermanDate, das erste passende gilt
+var GermanDatePatterns = []string{
+	"D.M.YYYY h:mm:ss", "D.M.YYYY h:mm", "D.M.YYYY",
+	"D.M.YY h:mm", "D.M.YY",
+	"D. MMMM YYYY", "D. MMM YYYY", "D.MMMM YYYY",
+	"DDMMYYYY", "DDMMYY",
+	"YYYY-MM-DD hh:mm:ss", "YYYY-MM-DD hh:mm", "YYYY-MM-DD",
+}
+
+// ParseGermanDate #"7.3.2020", "07.03.20 14:30", "7. März 2020", "07032020", "7.3." (laufendes Jahr),
+// "heute", "gestern", "morgen"; Zeitzone time.Local
+func ParseGermanDate(s string) (time.Time, error) {
+	s = strings.Join(strings.Fields(s), " ")
+
+	now := Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	switch strings.ToLower(s) {
+	case "heute":
+		return today, nil
+	case "gestern":
+		return today.AddDate(0, 0, -1), nil
+	case "morgen":
+		return today.AddDate(0, 0, 1), nil
+	}
+
+	// "7.3." bzw. "7.3. 14:30" -> laufendes Jahr
+	in := s
+	if f := strings.SplitN(s, " ", 2); strings.HasSuffix(f[0], ".") && strings.Count(f[0], ".") == 2 {
+		f[0] += strconv.Itoa(today.Year())
+		s = strings.Join(f, " ")
+	}
+
+	for _, p := range GermanDatePatterns {
+		if t, err := ParseTime(s, p); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date: %q", in)
+}
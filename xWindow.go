@@ -0,0 +1,378 @@
+package xt
+
+// ----------------------------------------------------------------------------------
+// xWindow.go for Go's xt package
+// Copyright 2026 by Waldemar Urbas
+//-----------------------------------------------------------------------------------
+// This Source Code Form is subject to the terms of the 'MIT License'
+// A short and simple permissive license with conditions only requiring
+// preservation of copyright and license notices.  Licensed works, modifications,
+// and larger works may be distributed under different terms and without source code.
+// ----------------------------------------------------------------------------------
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const daySeconds = 24 * 60 * 60
+
+// windowHorizon #CurrentEnd: weiter reichende Fenster gelten als immer offen
+const windowHorizon = 7 * 24 * time.Hour
+
+// TimeWindow #Zeitfenster "08:00-18:00", "22:00-06:00", "08:00:30-12:00", "1400-2200", "mo-fr 08:00-18:00"
+//
+// Das Ende ist exklusiv, ein Fenster ueber Mitternacht gehoert zum Wochentag seines Beginns.
+type TimeWindow struct {
+	from int   // Sekunde des Tages
+	to   int   // exklusiv, > daySeconds bei Fenster ueber Mitternacht
+	days uint8 // Wochentage des Beginns, 0 = alle
+}
+
+// ParseTimeWindow #
+func ParseTimeWindow(s string) (TimeWindow, error) {
+	return parseWindow(s, false)
+}
+
+// permitDash #"12:00 - 18:00" wie "12:00-18:00"
+var permitDash = regexp.MustCompile(`(\d)\s*-\s*(\d)`)
+
+// parseWindow #permit: Syntax von PermitHour, Endminute inklusive, ohne Ende bis Mitternacht,
+// "14-22" in Stunden, Leerzeichen um "-" und "12:00-12:00" (eine Minute) wie bisher erlaubt
+func parseWindow(s string, permit bool) (TimeWindow, error) {
+	var w TimeWindow
+
+	if permit {
+		s = permitDash.ReplaceAllString(s, "$1-$2")
+		if f := strings.Fields(s); len(f) > 0 && !strings.Contains(f[len(f)-1], "-") {
+			s += "-24:00"
+		}
+	}
+
+	f := strings.Fields(s)
+	if len(f) == 0 || len(f) > 2 {
+		return w, fmt.Errorf("time window %q: [days] hh:mm-hh:mm expected", s)
+	}
+
+	if len(f) == 2 {
+		for _, d := range strings.Split(f[0], ",") {
+			bits, ok := weekdayRange(d)
+			if !ok {
+				return w, fmt.Errorf("time window %q: invalid weekday %q", s, d)
+			}
+			w.days |= bits
+		}
+	}
+
+	r := strings.Split(f[len(f)-1], "-")
+	if len(r) != 2 {
+		return w, fmt.Errorf("time window %q: hh:mm-hh:mm expected", s)
+	}
+
+	daySecond := parseDaySecond
+	if permit {
+		daySecond = permitDaySecond
+	}
+
+	var err error
+	if w.from, err = daySecond(r[0]); err != nil {
+		return w, fmt.Errorf("time window %q: %v", s, err)
+	}
+
+	if w.to, err = daySecond(r[1]); err != nil {
+		return w, fmt.Errorf("time window %q: %v", s, err)
+	}
+
+	if w.from == daySeconds {
+		return w, fmt.Errorf("time window %q: start 24:00 not allowed", s)
+	}
+
+	if w.from == w.to && !permit {
+		return w, fmt.Errorf("time window %q: empty window", s)
+	}
+
+	if w.to < w.from {
+		w.to += daySeconds
+	}
+
+	if permit {
+		if w.to+60 <= daySeconds || w.to > daySeconds {
+			w.to += 60
+		} else {
+			w.to = daySeconds
+		}
+	}
+
+	return w, nil
+}
+
+// permitDaySecond #wie parseDaySecond, "0" bis "24" sind volle Stunden
+func permitDaySecond(s string) (int, error) {
+	if len(s) > 0 && len(s) <= 2 {
+		if h, err := strconv.Atoi(s); err == nil && h >= 0 && h <= 24 {
+			return h * 3600, nil
+		}
+	}
+	return parseDaySecond(s)
+}
+
+// parseDaySecond #"hh:mm", "hh:mm:ss", "hhmm", "hmm", "hhmmss", "hmmss", "24:00" -> Sekunde des Tages
+func parseDaySecond(s string) (int, error) {
+	var p []string
+	if strings.Contains(s, ":") {
+		p = strings.Split(s, ":")
+	} else if len(s) >= 3 && len(s) <= 6 {
+		// "800" = 08:00, "80000" = 08:00:00
+		x := s
+		if len(x)%2 == 1 {
+			x = "0" + x
+		}
+		for i := 0; i < len(x); i += 2 {
+			p = append(p, x[i:i+2])
+		}
+	}
+
+	if len(p) < 2 || len(p) > 3 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+
+	var v [3]int
+	for i, x := range p {
+		n, err := strconv.Atoi(x)
+		if err != nil || n < 0 || len(x) > 2 {
+			return 0, fmt.Errorf("invalid time %q", s)
+		}
+		v[i] = n
+	}
+
+	if v[1] > 59 || v[2] > 59 || v[0] > 24 || (v[0] == 24 && v[1]+v[2] > 0) {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+
+	return v[0]*3600 + v[1]*60 + v[2], nil
+}
+
+func (w TimeWindow) String() string {
+	hms := func(x int) string {
+		if x%60 != 0 {
+			return fmt.Sprintf("%02d:%02d:%02d", x/3600, x/60%60, x%60)
+		}
+		return fmt.Sprintf("%02d:%02d", x/3600, x/60%60)
+	}
+
+	s := hms(w.from) + "-" + hms(w.to%daySeconds)
+	if w.to == daySeconds {
+		s = hms(w.from) + "-24:00"
+	}
+
+	if w.days != 0 && w.days != 0x7f {
+		var d []string
+		for i := 0; i < 7; i++ {
+			if w.days&(1<<uint(i)) != 0 {
+				d = append(d, strconv.Itoa(i))
+			}
+		}
+		s = strings.Join(d, ",") + " " + s
+	}
+
+	return s
+}
+
+// Duration #Laenge des Fensters
+func (w TimeWindow) Duration() time.Duration {
+	return time.Duration(w.to-w.from) * time.Second
+}
+
+// dayOK #Fenster beginnt am Tag von d
+func (w TimeWindow) dayOK(d time.Time) bool {
+	return w.days == 0 || w.days&(1<<uint(d.Weekday())) != 0
+}
+
+// span #Beginn und Ende des Fensters, das am Tag von d beginnt
+func (w TimeWindow) span(d time.Time) (time.Time, time.Time) {
+	y, m, dd := d.Date()
+	loc := d.Location()
+	return time.Date(y, m, dd, 0, 0, w.from, 0, loc), time.Date(y, m, dd, 0, 0, w.to, 0, loc)
+}
+
+// Contains #Zeitzone von t
+func (w TimeWindow) Contains(t time.Time) bool {
+	for off := -1; off <= 0; off++ {
+		d := t.AddDate(0, 0, off)
+		if !w.dayOK(d) {
+			continue
+		}
+
+		if b, e := w.span(d); !t.Before(b) && t.Before(e) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WindowSet #mehrere Zeitfenster, optional in fester Zeitzone
+type WindowSet struct {
+	Windows  []TimeWindow
+	Location *time.Location // nil = Zeitzone von t
+}
+
+// ParseWindowSet #je Fenster ein String, siehe ParseTimeWindow
+func ParseWindowSet(specs ...string) (*WindowSet, error) {
+	ws := &WindowSet{}
+
+	var errs []string
+	for _, s := range specs {
+		w, err := ParseTimeWindow(s)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		ws.Windows = append(ws.Windows, w)
+	}
+
+	if len(errs) > 0 {
+		return ws, fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+
+	return ws, nil
+}
+
+// In #Kopie mit fester Zeitzone
+func (ws *WindowSet) In(loc *time.Location) *WindowSet {
+	c := *ws
+	c.Location = loc
+	return &c
+}
+
+func (ws *WindowSet) in(t time.Time) time.Time {
+	if ws.Location != nil {
+		return t.In(ws.Location)
+	}
+	return t
+}
+
+// Contains #
+func (ws *WindowSet) Contains(t time.Time) bool {
+	t = ws.in(t)
+	for _, w := range ws.Windows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NextStart #t, wenn t in einem Fenster liegt, sonst Beginn des naechsten; Zero ohne Fenster
+func (ws *WindowSet) NextStart(t time.Time) time.Time {
+	t = ws.in(t)
+	if ws.Contains(t) {
+		return t
+	}
+
+	var next time.Time
+	for off := 0; off <= 7; off++ {
+		d := t.AddDate(0, 0, off)
+		for _, w := range ws.Windows {
+			if !w.dayOK(d) {
+				continue
+			}
+
+			if b, _ := w.span(d); b.After(t) && (next.IsZero() || b.Before(next)) {
+				next = b
+			}
+		}
+
+		if !next.IsZero() {
+			break
+		}
+	}
+
+	return next
+}
+
+// CurrentEnd #Ende des Fensters um t, anschliessende Fenster werden zusammengefasst;
+// reicht das Ende mehr als 7 Tage ueber t hinaus (z.B. "00:00-24:00"), ist es immer offen: Zero und true
+func (ws *WindowSet) CurrentEnd(t time.Time) (time.Time, bool) {
+	t = ws.in(t)
+
+	var end time.Time
+	for changed := true; changed; {
+		changed = false
+
+		if !end.IsZero() && end.Sub(t) > windowHorizon {
+			return time.Time{}, true
+		}
+
+		// Fenster, die ab dem Vortag von t bzw. end beginnen
+		at := t
+		if !end.IsZero() {
+			at = end
+		}
+
+		for off := -1; off <= 0; off++ {
+			d := at.AddDate(0, 0, off)
+			for _, w := range ws.Windows {
+				if !w.dayOK(d) {
+					continue
+				}
+
+				b, e := w.span(d)
+				if end.IsZero() {
+					if !t.Before(b) && t.Before(e) {
+						end = e
+						changed = true
+					}
+				} else if !end.Before(b) && e.After(end) {
+					end = e
+					changed = true
+				}
+			}
+		}
+	}
+
+	return end, !end.IsZero()
+}
+
+// permitWindow #PermitHour: Endminute inklusive, "1400" = ab 14:00 bis Mitternacht
+func permitWindow(s string) (TimeWindow, error) {
+	return parseWindow(s, true)
+}
+
+// PermitHourErr #wie PermitHour, ungueltige Eintraege werden als Fehler geliefert
+func PermitHourErr(t time.Time, sh []string) (bool, error) {
+	var errs []string
+	ok := false
+	for _, s := range sh {
+		w, err := permitWindow(s)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		ok = ok || w.Contains(t)
+	}
+
+	if len(errs) > 0 {
+		return ok, fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+
+	return ok, nil
+}
+
+// NextPermitted #naechster Zeitpunkt, zu dem PermitHour(t, sh) erfuellt ist; t, wenn bereits erlaubt
+func NextPermitted(t time.Time, sh []string) (time.Time, error) {
+	ws := &WindowSet{}
+	for _, s := range sh {
+		w, err := permitWindow(s)
+		if err != nil {
+			return time.Time{}, err
+		}
+		ws.Windows = append(ws.Windows, w)
+	}
+
+	return ws.NextStart(t), nil
+}